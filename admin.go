@@ -0,0 +1,78 @@
+package testcontainers_wiremock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const adminPath = "/__admin"
+
+// adminRequest sends a request to the WireMock admin API, the body is sent as JSON if not nil
+// and the JSON response is decoded into out if not nil
+func (c *WireMockContainer) adminRequest(ctx context.Context, method string, endpoint string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("%s %s: build request error: %w", method, endpoint, err)
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.uri+adminPath+endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("%s %s: build request error: %w", method, endpoint, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: request error: %w", method, endpoint, err)
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("%s %s: read response error: %w", method, endpoint, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: bad response status: %d, response: %s", method, endpoint, res.StatusCode, content)
+	}
+
+	if out != nil && len(content) > 0 {
+		if err := json.Unmarshal(content, out); err != nil {
+			return fmt.Errorf("%s %s: read json error: %w", method, endpoint, err)
+		}
+	}
+
+	return nil
+}
+
+// Reset restores the stub mappings loaded from files, clears the request journal,
+// resets all the scenarios to their initial state and removes the global delays
+func (c *WireMockContainer) Reset(ctx context.Context) error {
+	if err := c.adminRequest(ctx, http.MethodPost, "/mappings/reset", nil, nil); err != nil {
+		return fmt.Errorf("reset mappings: %w", err)
+	}
+
+	if err := c.adminRequest(ctx, http.MethodDelete, "/requests", nil, nil); err != nil {
+		return fmt.Errorf("reset requests: %w", err)
+	}
+
+	if err := c.adminRequest(ctx, http.MethodPost, "/scenarios/reset", nil, nil); err != nil {
+		return fmt.Errorf("reset scenarios: %w", err)
+	}
+
+	if err := c.ClearGlobalDelays(ctx); err != nil {
+		return fmt.Errorf("reset: %w", err)
+	}
+
+	return nil
+}
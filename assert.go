@@ -0,0 +1,165 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// maxClosestRequests is the number of near misses printed when an assertion fails
+const maxClosestRequests = 3
+
+// AssertCalled checks that exactly times requests matching the pattern were received.
+// On failure, it reports the error with the closest requests and returns false.
+func (c *WireMockContainer) AssertCalled(t testing.TB, r *wiremock.Request, times int) bool {
+	t.Helper()
+	return c.assertCount(t, r, fmt.Sprintf("exactly %d", times), func(count int) bool { return count == times })
+}
+
+// AssertNotCalled checks that no request matching the pattern was received
+func (c *WireMockContainer) AssertNotCalled(t testing.TB, r *wiremock.Request) bool {
+	t.Helper()
+	return c.AssertCalled(t, r, 0)
+}
+
+// AssertCalledAtLeast checks that at least times requests matching the pattern were received
+func (c *WireMockContainer) AssertCalledAtLeast(t testing.TB, r *wiremock.Request, times int) bool {
+	t.Helper()
+	return c.assertCount(t, r, fmt.Sprintf("at least %d", times), func(count int) bool { return count >= times })
+}
+
+// AssertCalledInOrder checks that requests matching each of the patterns were received one after the other,
+// other requests may have been received in between
+func (c *WireMockContainer) AssertCalledInOrder(t testing.TB, patterns ...*wiremock.Request) bool {
+	t.Helper()
+	ctx := context.Background()
+
+	received, err := c.Requests(ctx, RequestFilter{})
+	if err != nil {
+		t.Errorf("failed to get the request journal: %s", err)
+		return false
+	}
+	// Identical requests received within the same millisecond share a key, so each key lists all their positions
+	positions := make(map[string][]int, len(received))
+	for i, event := range received {
+		key := event.Request.key()
+		positions[key] = append(positions[key], i)
+	}
+
+	previous := -1
+	for i, pattern := range patterns {
+		matching, err := c.findRequests(ctx, pattern)
+		if err != nil {
+			t.Errorf("failed to find the requests: %s", err)
+			return false
+		}
+
+		// The positions taken increase strictly, so that each request matches a single pattern
+		next := -1
+		for _, request := range matching {
+			for _, position := range positions[request.key()] {
+				if position > previous {
+					if next < 0 || position < next {
+						next = position
+					}
+					break
+				}
+			}
+		}
+		if next >= 0 {
+			previous = next
+			continue
+		}
+
+		var message strings.Builder
+		message.WriteString("expected requests matching, in order:\n")
+		for j, p := range patterns {
+			fmt.Fprintf(&message, "  %d. %s\n", j+1, patternJSON(p))
+		}
+		if i == 0 {
+			fmt.Fprintf(&message, "but no request matched #1\n")
+		} else {
+			fmt.Fprintf(&message, "but no request matched #%d after the one matching #%d\n", i+1, i)
+		}
+		fmt.Fprintf(&message, "Received requests (%d):\n", len(received))
+		for j, event := range received {
+			fmt.Fprintf(&message, "  %d. %s %s\n", j+1, event.Request.Method, event.Request.URL)
+		}
+		t.Error(message.String())
+
+		return false
+	}
+
+	return true
+}
+
+func (c *WireMockContainer) assertCount(t testing.TB, r *wiremock.Request, expected string, ok func(count int) bool) bool {
+	t.Helper()
+	ctx := context.Background()
+
+	matching, err := c.findRequests(ctx, r)
+	if err != nil {
+		t.Errorf("failed to find the requests: %s", err)
+		return false
+	}
+	if ok(len(matching)) {
+		return true
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "expected %s requests matching\n  %s\nbut got %d\n", expected, patternJSON(r), len(matching))
+	if len(matching) > 0 {
+		message.WriteString("Matching requests:\n")
+		for _, request := range matching {
+			fmt.Fprintf(&message, "  %s %s\n", request.Method, request.URL)
+		}
+	}
+
+	c.writeClosestRequests(ctx, &message, r)
+	t.Error(message.String())
+
+	return false
+}
+
+// writeClosestRequests describes the requests which almost matched the pattern, and how they differed
+func (c *WireMockContainer) writeClosestRequests(ctx context.Context, message *strings.Builder, r *wiremock.Request) {
+	var nearMisses nearMissesResponse
+	if err := c.adminRequest(ctx, http.MethodPost, "/near-misses/request-pattern", r, &nearMisses); err != nil {
+		fmt.Fprintf(message, "Closest requests unavailable: %s\n", err)
+	} else if len(nearMisses.NearMisses) > 0 {
+		message.WriteString("Closest requests:\n")
+		for _, miss := range nearMisses.NearMisses[:min(len(nearMisses.NearMisses), maxClosestRequests)] {
+			fmt.Fprintf(message, "  %s %s (distance %.2f)\n", miss.Request.Method, miss.Request.URL, miss.MatchResult.Distance)
+			if miss.RequestPattern == nil {
+				continue
+			}
+			for _, difference := range requestDifferences(miss.Request, *miss.RequestPattern) {
+				fmt.Fprintf(message, "    %s\n", difference)
+			}
+		}
+	}
+}
+
+// findRequests returns the logged requests matching the pattern
+func (c *WireMockContainer) findRequests(ctx context.Context, r *wiremock.Request) ([]LoggedRequest, error) {
+	var found loggedRequestsResponse
+	if err := c.adminRequest(ctx, http.MethodPost, "/requests/find", r, &found); err != nil {
+		return nil, fmt.Errorf("find requests: %w", err)
+	}
+
+	return found.Requests, nil
+}
+
+func patternJSON(r *wiremock.Request) string {
+	content, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Sprintf("%+v", r)
+	}
+
+	return string(content)
+}
@@ -0,0 +1,139 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// newFakeJournalContainer serves a journal where GET /hello then POST /orders were received
+func newFakeJournalContainer(t *testing.T) *WireMockContainer {
+	t.Helper()
+
+	hello := `{"method": "GET", "url": "/hello", "absoluteUrl": "http://localhost/hello", "loggedDate": 1}`
+	orders := `{"method": "POST", "url": "/orders", "absoluteUrl": "http://localhost/orders", "loggedDate": 2}`
+
+	return newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/requests":
+			_, _ = w.Write([]byte(`{"requests": [{"request": ` + orders + `}, {"request": ` + hello + `}]}`))
+		case "/__admin/requests/find":
+			var pattern map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&pattern); err != nil {
+				t.Error(err)
+			}
+			switch pattern["method"].(string) + " " + pattern["urlPath"].(string) {
+			case "GET /hello":
+				_, _ = w.Write([]byte(`{"requests": [` + hello + `]}`))
+			case "POST /orders":
+				_, _ = w.Write([]byte(`{"requests": [` + orders + `]}`))
+			default:
+				_, _ = w.Write([]byte(`{"requests": []}`))
+			}
+		case "/__admin/near-misses/request-pattern":
+			_, _ = w.Write([]byte(`{"nearMisses": [{"request": ` + orders + `,
+				"requestPattern": {"method": "PUT", "urlPath": "/orders"}, "matchResult": {"distance": 0.1}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestAssertCalled(t *testing.T) {
+	container := newFakeJournalContainer(t)
+	hello := wiremock.NewRequest(http.MethodGet, wiremock.URLPathEqualTo("/hello"))
+	orders := wiremock.NewRequest(http.MethodPut, wiremock.URLPathEqualTo("/orders"))
+
+	tb := &recordingTB{}
+	if !container.AssertCalled(tb, hello, 1) || !container.AssertCalledAtLeast(tb, hello, 1) {
+		t.Fatalf("expected the assertions to pass but got %q", tb.errors)
+	}
+	if container.AssertCalled(tb, hello, 2) {
+		t.Fatal("expected the assertion to fail")
+	}
+	if !strings.Contains(tb.errors[0], "expected exactly 2 requests matching\n") ||
+		!strings.Contains(tb.errors[0], "but got 1\nMatching requests:\n  GET /hello\n") {
+		t.Fatalf("unexpected error %q", tb.errors[0])
+	}
+
+	tb = &recordingTB{}
+	if container.AssertCalledAtLeast(tb, orders, 1) {
+		t.Fatal("expected the assertion to fail")
+	}
+	expected := "Closest requests:\n  POST /orders (distance 0.10)\n    method: expected PUT but was POST\n"
+	if !strings.Contains(tb.errors[0], expected) {
+		t.Fatalf("expected the error to contain %q but got %q", expected, tb.errors[0])
+	}
+
+	tb = &recordingTB{}
+	if !container.AssertNotCalled(tb, wiremock.NewRequest(http.MethodGet, wiremock.URLPathEqualTo("/other"))) {
+		t.Fatalf("expected the assertion to pass but got %q", tb.errors)
+	}
+}
+
+func TestAssertCalledInOrder(t *testing.T) {
+	container := newFakeJournalContainer(t)
+	hello := wiremock.NewRequest(http.MethodGet, wiremock.URLPathEqualTo("/hello"))
+	orders := wiremock.NewRequest(http.MethodPost, wiremock.URLPathEqualTo("/orders"))
+
+	tb := &recordingTB{}
+	if !container.AssertCalledInOrder(tb, hello, orders) {
+		t.Fatalf("expected the assertion to pass but got %q", tb.errors)
+	}
+
+	if container.AssertCalledInOrder(tb, orders, hello) {
+		t.Fatal("expected the assertion to fail")
+	}
+	expected := "but no request matched #2 after the one matching #1\nReceived requests (2):\n  1. GET /hello\n  2. POST /orders\n"
+	if !strings.Contains(tb.errors[0], expected) {
+		t.Fatalf("expected the error to contain %q but got %q", expected, tb.errors[0])
+	}
+}
+
+func TestAssertCalledInOrderRetries(t *testing.T) {
+	// The client retried within the same millisecond
+	retry := `{"method": "GET", "url": "/retry", "absoluteUrl": "http://localhost/retry", "loggedDate": 5}`
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/requests":
+			_, _ = w.Write([]byte(`{"requests": [{"request": ` + retry + `}, {"request": ` + retry + `}]}`))
+		case "/__admin/requests/find":
+			_, _ = w.Write([]byte(`{"requests": [` + retry + `, ` + retry + `]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	pattern := wiremock.NewRequest(http.MethodGet, wiremock.URLPathEqualTo("/retry"))
+
+	tb := &recordingTB{}
+	if !container.AssertCalledInOrder(tb, pattern, pattern) {
+		t.Fatalf("expected the assertion to pass but got %q", tb.errors)
+	}
+	if container.AssertCalledInOrder(tb, pattern, pattern, pattern) {
+		t.Fatal("expected the assertion to fail with a single request per pattern")
+	}
+}
+
+func TestWireMockAssertCalled(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if _, _, err := SendHttpGet(container, "/hello", nil); err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+	}
+
+	container.AssertCalled(t, wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/hello")), 2)
+	container.AssertNotCalled(t, wiremock.NewRequest(http.MethodPost, wiremock.URLEqualTo("/hello")))
+}
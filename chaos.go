@@ -0,0 +1,363 @@
+package testcontainers_wiremock
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+const (
+	// maxChaosCycle bounds the cycle, as each of its steps is a stub
+	maxChaosCycle           = 100
+	defaultChaosDelay       = time.Second
+	defaultChaosErrorStatus = http.StatusServiceUnavailable
+	defaultChaosFault       = wiremock.FaultConnectionResetByPeer
+	// chaosMetadataKey is the key, under scopeMetadataKey, describing the perturbation of a chaos stub
+	chaosMetadataKey = "chaos"
+)
+
+// ChaosKind is the perturbation applied to a response
+type ChaosKind string
+
+const (
+	ChaosNone  ChaosKind = "none"
+	ChaosDelay ChaosKind = "delay"
+	ChaosError ChaosKind = "error"
+	ChaosFault ChaosKind = "fault"
+)
+
+// ChaosProfile tells which share of the requests matched by each stub is perturbed.
+// The perturbed requests of a stub are drawn from the seed and the request pattern, name and priority of the stub,
+// so that a failing run can be reproduced.
+type ChaosProfile struct {
+	Seed uint64
+	// Cycle is the number of successive requests over which the rates apply exactly,
+	// so each rate must be a multiple of 1/Cycle. By default, it is the shortest cycle
+	// in which every rate is exact, e.g. 20 for rates of 0.1 and 0.05.
+	// Each request of the cycle is a stub, so fine-grained rates are expensive: the cycle is at most 100.
+	Cycle int
+
+	// DelayRate is the share of responses delayed by Delay, 1s by default
+	DelayRate float64
+	Delay     time.Duration
+	// ErrorRate is the share of responses replaced by an ErrorStatus response, 503 by default
+	ErrorRate   float64
+	ErrorStatus int
+	// FaultRate is the share of responses replaced by Fault, wiremock.FaultConnectionResetByPeer by default
+	FaultRate float64
+	Fault     wiremock.Fault
+}
+
+// Perturbation is a request perturbed by the chaos profile
+type Perturbation struct {
+	Event ServeEvent
+	Kind  ChaosKind
+	// StubID is the id of the stub the chaos stub was derived from
+	StubID string
+	// Step is the position of the request in the cycle of the stub
+	Step int
+}
+
+func (p Perturbation) String() string {
+	return fmt.Sprintf("%s %s: %s (stub %s, step %d)", p.Event.Request.Method, p.Event.Request.URL, p.Kind, p.StubID, p.Step)
+}
+
+// chaosMetadata is stored in the metadata of the chaos stubs to report the perturbations
+type chaosMetadata struct {
+	Stub string    `json:"stub"`
+	Step int       `json:"step"`
+	Kind ChaosKind `json:"kind"`
+	Seed uint64    `json:"seed"`
+}
+
+func (p ChaosProfile) withDefaults() ChaosProfile {
+	p.Delay = cmp.Or(p.Delay, defaultChaosDelay)
+	p.ErrorStatus = cmp.Or(p.ErrorStatus, defaultChaosErrorStatus)
+	p.Fault = cmp.Or(p.Fault, defaultChaosFault)
+
+	return p
+}
+
+func (p ChaosProfile) validate() error {
+	for _, rate := range []float64{p.DelayRate, p.ErrorRate, p.FaultRate} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("chaos rates must be between 0 and 1, got %v", rate)
+		}
+	}
+	if p.DelayRate+p.ErrorRate+p.FaultRate > 1 {
+		return errors.New("the sum of the chaos rates must not exceed 1")
+	}
+	if p.Cycle < 0 {
+		return fmt.Errorf("chaos cycle must be positive, got %d", p.Cycle)
+	}
+
+	return nil
+}
+
+// cycleLength returns the cycle of the profile, or the shortest one in which every rate is exact
+func (p ChaosProfile) cycleLength() (int, error) {
+	if p.Cycle > maxChaosCycle {
+		return 0, fmt.Errorf("chaos cycle must be at most %d, got %d", maxChaosCycle, p.Cycle)
+	}
+	if p.Cycle > 0 {
+		for _, rate := range []float64{p.DelayRate, p.ErrorRate, p.FaultRate} {
+			if !exactRate(rate, p.Cycle) {
+				return 0, fmt.Errorf("chaos rate %v is not a multiple of 1/%d", rate, p.Cycle)
+			}
+		}
+		return p.Cycle, nil
+	}
+
+	for cycle := 1; cycle <= maxChaosCycle; cycle++ {
+		if exactRate(p.DelayRate, cycle) && exactRate(p.ErrorRate, cycle) && exactRate(p.FaultRate, cycle) {
+			return cycle, nil
+		}
+	}
+
+	return 0, fmt.Errorf("chaos rates must be multiples of 1/%d at most", maxChaosCycle)
+}
+
+// exactRate tells whether the rate is a whole number of requests per cycle, up to the float rounding
+func exactRate(rate float64, cycle int) bool {
+	count := rate * float64(cycle)
+	return math.Abs(count-math.Round(count)) < 1e-9
+}
+
+// cycle draws the perturbation of each step of the cycle of a stub
+func (p ChaosProfile) cycle(rng *rand.Rand) []ChaosKind {
+	steps := make([]ChaosKind, 0, p.Cycle)
+	for _, share := range []struct {
+		kind ChaosKind
+		rate float64
+	}{{ChaosDelay, p.DelayRate}, {ChaosError, p.ErrorRate}, {ChaosFault, p.FaultRate}} {
+		count := int(math.Round(share.rate * float64(p.Cycle)))
+		for range min(count, p.Cycle-len(steps)) {
+			steps = append(steps, share.kind)
+		}
+	}
+	for len(steps) < p.Cycle {
+		steps = append(steps, ChaosNone)
+	}
+	rng.Shuffle(len(steps), func(i, j int) { steps[i], steps[j] = steps[j], steps[i] })
+
+	return steps
+}
+
+// ApplyChaos replaces each stub by a scenario cycling through the normal and perturbed responses of the profile.
+// Stubs which are already part of a scenario, or persistent, are left untouched, and so are all the stubs
+// when every rate is 0. Reset restores the stubs loaded from files, also after a failure partway.
+func (c *WireMockContainer) ApplyChaos(ctx context.Context, profile ChaosProfile) error {
+	profile = profile.withDefaults()
+	if err := profile.validate(); err != nil {
+		return err
+	}
+	if profile.DelayRate+profile.ErrorRate+profile.FaultRate == 0 {
+		return nil
+	}
+	cycle, err := profile.cycleLength()
+	if err != nil {
+		return err
+	}
+	profile.Cycle = cycle
+
+	var res mappingsResponse
+	if err := c.adminRequest(ctx, http.MethodGet, "/mappings", nil, &res); err != nil {
+		return fmt.Errorf("apply chaos: %w", err)
+	}
+
+	targets := make([]chaosTarget, 0, len(res.Mappings))
+	for _, mapping := range res.Mappings {
+		// Removing a persistent stub would delete its file
+		if mapping.ScenarioName != "" || mapping.Persistent {
+			continue
+		}
+		target, err := newChaosTarget(mapping)
+		if err != nil {
+			return fmt.Errorf("apply chaos: %w", err)
+		}
+		targets = append(targets, target)
+	}
+	// The ids are generated when the stubs are loaded, so the stubs are told apart by their content
+	slices.SortFunc(targets, func(a, b chaosTarget) int {
+		return cmp.Or(cmp.Compare(a.key, b.key), cmp.Compare(a.content, b.content))
+	})
+	for i := 1; i < len(targets); i++ {
+		if targets[i].key == targets[i-1].key {
+			targets[i].duplicate = targets[i-1].duplicate + 1
+		}
+	}
+
+	// The chaos stubs are all derived before the stubs are modified
+	replacements := make([][]map[string]any, 0, len(targets))
+	for _, target := range targets {
+		// Each stub has its own generator, so that adding a stub leaves the perturbations of the others unchanged
+		rng := rand.New(rand.NewPCG(profile.Seed, target.seed+uint64(target.duplicate)))
+		stubs, err := chaosStubs(target.mapping, target.scenarioName(), profile, profile.cycle(rng))
+		if err != nil {
+			return fmt.Errorf("apply chaos: %w", err)
+		}
+		replacements = append(replacements, stubs)
+	}
+
+	// The original stub is only removed once its replacements are added,
+	// so a failure leaves each stub either untouched or shadowed by its chaos stubs
+	for i, target := range targets {
+		for _, stub := range replacements[i] {
+			if err := c.adminRequest(ctx, http.MethodPost, "/mappings", stub, nil); err != nil {
+				return fmt.Errorf("apply chaos, Reset restores the stubs: %w", err)
+			}
+		}
+		if err := c.adminRequest(ctx, http.MethodDelete, "/mappings/"+url.PathEscape(target.mapping.ID), nil, nil); err != nil {
+			return fmt.Errorf("apply chaos, Reset restores the stubs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// chaosTarget is a stub replaced by ApplyChaos
+type chaosTarget struct {
+	mapping StubMapping
+	// key identifies the stub across runs, from its request pattern, name and priority
+	key  string
+	seed uint64
+	// content is the stub without its ids, to order the stubs sharing a key
+	content string
+	// duplicate is the position of the stub among the ones sharing its key
+	duplicate int
+}
+
+func newChaosTarget(mapping StubMapping) (chaosTarget, error) {
+	raw, err := mapping.mappingJSON()
+	if err != nil {
+		return chaosTarget{}, err
+	}
+	var stub map[string]any
+	if err := json.Unmarshal(raw, &stub); err != nil {
+		return chaosTarget{}, err
+	}
+	delete(stub, "id")
+	delete(stub, "uuid")
+	// Marshalling a map sorts its keys
+	content, err := json.Marshal(stub)
+	if err != nil {
+		return chaosTarget{}, err
+	}
+	pattern, err := json.Marshal([]any{stub["request"], mapping.Name, mapping.Priority})
+	if err != nil {
+		return chaosTarget{}, err
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write(pattern)
+	seed := hash.Sum64()
+
+	return chaosTarget{mapping: mapping, key: fmt.Sprintf("%016x", seed), seed: seed, content: string(content)}, nil
+}
+
+func (t chaosTarget) scenarioName() string {
+	if t.duplicate > 0 {
+		return fmt.Sprintf("chaos %s-%d", t.key, t.duplicate+1)
+	}
+
+	return "chaos " + t.key
+}
+
+// chaosStubs derives a chaos stub per step of the cycle from the mapping
+func chaosStubs(mapping StubMapping, scenarioName string, profile ChaosProfile, steps []ChaosKind) ([]map[string]any, error) {
+	content, err := mapping.mappingJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	stubs := make([]map[string]any, 0, len(steps))
+	for i, kind := range steps {
+		var stub map[string]any
+		if err := json.Unmarshal(content, &stub); err != nil {
+			return nil, err
+		}
+		// WireMock generates new ids
+		delete(stub, "id")
+		delete(stub, "uuid")
+		delete(stub, "persistent")
+
+		stub["scenarioName"] = scenarioName
+		stub["requiredScenarioState"] = sequenceState(i)
+		stub["newScenarioState"] = sequenceState((i + 1) % len(steps))
+
+		metadata, _ := stub["metadata"].(map[string]any)
+		if metadata == nil {
+			metadata = make(map[string]any)
+		}
+		scope, _ := metadata[scopeMetadataKey].(map[string]any)
+		if scope == nil {
+			scope = make(map[string]any)
+		}
+		scope[chaosMetadataKey] = chaosMetadata{Stub: mapping.ID, Step: i, Kind: kind, Seed: profile.Seed}
+		metadata[scopeMetadataKey] = scope
+		stub["metadata"] = metadata
+
+		switch kind {
+		case ChaosDelay:
+			response, _ := stub["response"].(map[string]any)
+			if response == nil {
+				response = make(map[string]any)
+			}
+			response["fixedDelayMilliseconds"] = profile.Delay.Milliseconds()
+			stub["response"] = response
+		case ChaosError:
+			stub["response"] = map[string]any{"status": profile.ErrorStatus, "body": "Chaos error"}
+		case ChaosFault:
+			stub["response"] = map[string]any{"fault": profile.Fault}
+		}
+
+		stubs = append(stubs, stub)
+	}
+
+	return stubs, nil
+}
+
+// ChaosReport returns the requests perturbed by ApplyChaos, in the order they were received
+func (c *WireMockContainer) ChaosReport(ctx context.Context) ([]Perturbation, error) {
+	events, err := c.Requests(ctx, RequestFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("chaos report: %w", err)
+	}
+
+	var perturbations []Perturbation
+	for _, event := range events {
+		if event.StubMapping == nil {
+			continue
+		}
+		scope, _ := event.StubMapping.Metadata[scopeMetadataKey].(map[string]any)
+		if scope[chaosMetadataKey] == nil {
+			continue
+		}
+
+		content, err := json.Marshal(scope[chaosMetadataKey])
+		if err != nil {
+			return nil, fmt.Errorf("chaos report: %w", err)
+		}
+		var metadata chaosMetadata
+		if err := json.Unmarshal(content, &metadata); err != nil {
+			return nil, fmt.Errorf("chaos report: %w", err)
+		}
+
+		if metadata.Kind != ChaosNone {
+			perturbations = append(perturbations, Perturbation{Event: event, Kind: metadata.Kind, StubID: metadata.Stub, Step: metadata.Step})
+		}
+	}
+
+	return perturbations, nil
+}
@@ -0,0 +1,307 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// newFakeChaosContainer serves a plain stub with the given id, a stub part of a scenario, a persistent one
+// and the extra mappings, and records the chaos stubs posted
+func newFakeChaosContainer(t *testing.T, helloID string, extra ...string) (*WireMockContainer, *[]string, *[]map[string]any) {
+	t.Helper()
+
+	var deleted []string
+	var posted []map[string]any
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			mappings := append([]string{
+				`{"id": "scenario", "scenarioName": "login", "request": {"url": "/login"}, "response": {"status": 200}}`,
+				`{"id": "persistent", "persistent": true, "request": {"url": "/saved"}, "response": {"status": 200}}`,
+				`{"id": "` + helloID + `", "request": {"method": "GET", "url": "/hello"},
+				  "response": {"status": 200, "body": "Hello, world!"}, "metadata": {"owner": "team"}}`,
+			}, extra...)
+			_, _ = w.Write([]byte(`{"mappings": [` + strings.Join(mappings, ",") + `]}`))
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+		case http.MethodPost:
+			var stub map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&stub); err != nil {
+				t.Error(err)
+			}
+			posted = append(posted, stub)
+		}
+	})
+
+	return container, &deleted, &posted
+}
+
+func TestApplyChaos(t *testing.T) {
+	profile := ChaosProfile{Seed: 42, Cycle: 4, DelayRate: 0.25, ErrorRate: 0.25, FaultRate: 0.25}
+
+	container, deleted, posted := newFakeChaosContainer(t, "b3f1c2d4")
+	if err := container.ApplyChaos(context.Background(), profile); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(*deleted, []string{"/__admin/mappings/b3f1c2d4"}) {
+		t.Fatalf("expected only the plain stub to be replaced but got %v", *deleted)
+	}
+	if len(*posted) != profile.Cycle {
+		t.Fatalf("expected %d chaos stubs but got %d", profile.Cycle, len(*posted))
+	}
+
+	scenarioName, _ := (*posted)[0]["scenarioName"].(string)
+	if !strings.HasPrefix(scenarioName, "chaos ") || strings.Contains(scenarioName, "b3f1c2d4") {
+		t.Fatalf("expected a scenario name derived from the stub content but got %q", scenarioName)
+	}
+	kinds := make(map[ChaosKind]int)
+	for i, stub := range *posted {
+		if stub["scenarioName"] != scenarioName || stub["requiredScenarioState"] != sequenceState(i) ||
+			stub["newScenarioState"] != sequenceState((i+1)%profile.Cycle) {
+			t.Fatalf("expected the chaos stubs to be chained in a cycle but got %v", stub)
+		}
+		if stub["id"] != nil || stub["request"].(map[string]any)["url"] != "/hello" {
+			t.Fatalf("expected the chaos stub to match the original request but got %v", stub)
+		}
+
+		metadata := stub["metadata"].(map[string]any)
+		if metadata["owner"] != "team" {
+			t.Fatalf("expected the original metadata to be kept but got %v", metadata)
+		}
+		chaos := metadata[scopeMetadataKey].(map[string]any)[chaosMetadataKey].(map[string]any)
+		kind := ChaosKind(chaos["kind"].(string))
+		kinds[kind]++
+
+		response := stub["response"].(map[string]any)
+		switch kind {
+		case ChaosNone:
+			if response["body"] != "Hello, world!" || response["fixedDelayMilliseconds"] != nil {
+				t.Fatalf("expected the original response but got %v", response)
+			}
+		case ChaosDelay:
+			if response["body"] != "Hello, world!" || response["fixedDelayMilliseconds"] != 1000.0 {
+				t.Fatalf("expected the original response delayed but got %v", response)
+			}
+		case ChaosError:
+			if response["status"] != 503.0 {
+				t.Fatalf("expected an error response but got %v", response)
+			}
+		case ChaosFault:
+			if response["fault"] != string(wiremock.FaultConnectionResetByPeer) {
+				t.Fatalf("expected a fault but got %v", response)
+			}
+		}
+	}
+	expected := map[ChaosKind]int{ChaosNone: 1, ChaosDelay: 1, ChaosError: 1, ChaosFault: 1}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("expected perturbations %v but got %v", expected, kinds)
+	}
+
+	// The same seed gives the same perturbations, whatever the ids generated by WireMock and the other stubs
+	other, _, reproduced := newFakeChaosContainer(t, "0a9e8f7b",
+		`{"id": "00000000", "request": {"method": "GET", "url": "/other"}, "response": {"status": 200}}`)
+	if err := other.ApplyChaos(context.Background(), profile); err != nil {
+		t.Fatal(err)
+	}
+	var hello []map[string]any
+	for _, stub := range *reproduced {
+		if stub["scenarioName"] == scenarioName {
+			hello = append(hello, stub)
+		}
+	}
+	if len(*reproduced) != 2*profile.Cycle || !reflect.DeepEqual(chaosSteps(*posted), chaosSteps(hello)) {
+		t.Fatal("expected the same chaos stubs for the same seed")
+	}
+}
+
+// chaosSteps returns the scenario state and perturbation of each chaos stub
+func chaosSteps(stubs []map[string]any) []string {
+	steps := make([]string, 0, len(stubs))
+	for _, stub := range stubs {
+		chaos := stub["metadata"].(map[string]any)[scopeMetadataKey].(map[string]any)[chaosMetadataKey].(map[string]any)
+		steps = append(steps, stub["requiredScenarioState"].(string)+": "+chaos["kind"].(string))
+	}
+
+	return steps
+}
+
+func TestApplyChaosDuplicateStubs(t *testing.T) {
+	duplicate := `{"request": {"method": "GET", "url": "/hello"}, "response": {"status": 200, "body": "Hello, world!"},
+		"metadata": {"owner": "team"}}`
+	container, _, posted := newFakeChaosContainer(t, "b3f1c2d4", duplicate)
+	if err := container.ApplyChaos(context.Background(), ChaosProfile{Seed: 42, Cycle: 2, ErrorRate: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := make(map[any]int)
+	for _, stub := range *posted {
+		scenarios[stub["scenarioName"]]++
+	}
+	if len(scenarios) != 2 {
+		t.Fatalf("expected a scenario per stub but got %v", scenarios)
+	}
+}
+
+func TestApplyChaosReplacesBeforeRemoving(t *testing.T) {
+	var calls []string
+	failPost := false
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method)
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"mappings": [{"id": "hello", "request": {"url": "/hello"}, "response": {"status": 200}}]}`))
+		case http.MethodPost:
+			if failPost {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	})
+	ctx := context.Background()
+
+	if err := container.ApplyChaos(ctx, ChaosProfile{Seed: 1}); err != nil || len(calls) != 0 {
+		t.Fatalf("expected no stub to be replaced without perturbations but got %v, %v", calls, err)
+	}
+
+	if err := container.ApplyChaos(ctx, ChaosProfile{Seed: 1, ErrorRate: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{http.MethodGet, http.MethodPost, http.MethodPost, http.MethodDelete}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected the chaos stubs to be added before the stub is removed but got %v", calls)
+	}
+
+	calls = nil
+	failPost = true
+	err := container.ApplyChaos(ctx, ChaosProfile{Seed: 1, ErrorRate: 0.5})
+	if err == nil || !strings.Contains(err.Error(), "Reset restores the stubs") {
+		t.Fatalf("expected the error to tell how to restore the stubs but got %v", err)
+	}
+	if slices.Contains(calls, http.MethodDelete) {
+		t.Fatalf("expected the stub to be kept after a failure but got %v", calls)
+	}
+}
+
+func TestApplyChaosInvalidProfile(t *testing.T) {
+	container, _, _ := newFakeChaosContainer(t, "hello")
+
+	for _, profile := range []ChaosProfile{{ErrorRate: 1.5}, {ErrorRate: 0.6, FaultRate: 0.6}, {Cycle: -1}, {Cycle: 10, ErrorRate: 0.05}} {
+		if err := container.ApplyChaos(context.Background(), profile); err == nil {
+			t.Fatalf("expected an error for the profile %+v", profile)
+		}
+	}
+}
+
+func TestChaosProfileCycle(t *testing.T) {
+	for _, tc := range []struct {
+		profile ChaosProfile
+		cycle   int
+	}{
+		{ChaosProfile{}, 1},
+		{ChaosProfile{ErrorRate: 0.5}, 2},
+		{ChaosProfile{DelayRate: 0.1, ErrorRate: 0.05, FaultRate: 0.05}, 20},
+		{ChaosProfile{Cycle: 10, ErrorRate: 0.3}, 10},
+		{ChaosProfile{Cycle: 10, ErrorRate: 0.05}, 0},
+		{ChaosProfile{ErrorRate: 0.0001}, 0},
+		{ChaosProfile{ErrorRate: 0.123}, 0},
+		{ChaosProfile{Cycle: 200, ErrorRate: 0.5}, 0},
+	} {
+		cycle, err := tc.profile.cycleLength()
+		if tc.cycle == 0 {
+			if err == nil {
+				t.Fatalf("expected an error for the profile %+v but got the cycle %d", tc.profile, cycle)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cycle != tc.cycle {
+			t.Fatalf("expected a cycle of %d for the profile %+v but got %d", tc.cycle, tc.profile, cycle)
+		}
+	}
+
+	profile := ChaosProfile{Cycle: 20, DelayRate: 0.1, ErrorRate: 0.05, FaultRate: 0.05}
+	kinds := make(map[ChaosKind]int)
+	for _, kind := range profile.cycle(rand.New(rand.NewPCG(1, 1))) {
+		kinds[kind]++
+	}
+	expected := map[ChaosKind]int{ChaosNone: 16, ChaosDelay: 2, ChaosError: 1, ChaosFault: 1}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("expected perturbations %v but got %v", expected, kinds)
+	}
+}
+
+func TestChaosReport(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"requests": [
+			{"id": "3", "request": {"method": "GET", "url": "/hello", "loggedDate": 3}, "stubMapping": {"metadata":
+			 {"testcontainers": {"chaos": {"stub": "hello", "step": 1, "kind": "error", "seed": 42}}}}},
+			{"id": "2", "request": {"method": "GET", "url": "/hello", "loggedDate": 2}, "stubMapping": {"metadata":
+			 {"testcontainers": {"chaos": {"stub": "hello", "step": 0, "kind": "none", "seed": 42}}}}},
+			{"id": "1", "request": {"method": "GET", "url": "/other", "loggedDate": 1}, "stubMapping": {"id": "other"}}
+		]}`))
+	})
+
+	perturbations, err := container.ChaosReport(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(perturbations) != 1 || perturbations[0].String() != "GET /hello: error (stub hello, step 1)" {
+		t.Fatalf("expected the perturbed request but got %v", perturbations)
+	}
+}
+
+func TestWireMockChaos(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := container.ApplyChaos(ctx, ChaosProfile{Seed: 1, Cycle: 2, ErrorRate: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := make(map[int]int)
+	for range 4 {
+		statusCode, _, err := SendHttpGet(container, "/hello", nil)
+		if err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+		statuses[statusCode]++
+	}
+	if statuses[200] != 2 || statuses[503] != 2 {
+		t.Fatalf("expected half of the responses to be perturbed but got %v", statuses)
+	}
+
+	perturbations, err := container.ChaosReport(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(perturbations) != 2 || perturbations[0].Kind != ChaosError {
+		t.Fatalf("expected 2 errors in the report but got %v", perturbations)
+	}
+
+	if err := container.Reset(ctx); err != nil {
+		t.Fatal(err)
+	}
+	statusCode, _, err := SendHttpGet(container, "/hello", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 once reset but got %d", statusCode)
+	}
+}
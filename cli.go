@@ -0,0 +1,190 @@
+package testcontainers_wiremock
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cliArg is a WireMock command line option, flags have an empty value
+type cliArg struct {
+	name  string
+	value string
+}
+
+// listCLIArgs are the options taking a comma separated list, their values are merged rather than overridden
+var listCLIArgs = []string{"extensions"}
+
+// defaultCLIArgs are always passed to WireMock
+var defaultCLIArgs = []cliArg{{name: "disable-banner"}}
+
+// WithCLIArgs passes raw command line options to WireMock, e.g. "--port", "8080" or "--port=8080".
+// It is an escape hatch for the options not covered by the typed customizers.
+// When an option is passed several times, the last value wins.
+func WithCLIArgs(args ...string) Option {
+	return func(o *options) error {
+		parsed, err := parseCLIArgs(args)
+		if err != nil {
+			return err
+		}
+
+		for _, arg := range parsed {
+			o.cliArgs = setCLIArg(o.cliArgs, arg.name, arg.value)
+		}
+
+		return nil
+	}
+}
+
+// WithGlobalResponseTemplating enables response templating for all the stubs
+func WithGlobalResponseTemplating() Option {
+	return withCLIArg("global-response-templating", "")
+}
+
+// WithVerbose enables the WireMock verbose logging
+func WithVerbose() Option {
+	return withCLIArg("verbose", "")
+}
+
+// WithAsyncResponses enables asynchronous responses, served by the given number of threads
+func WithAsyncResponses(threads int) Option {
+	return func(o *options) error {
+		if threads <= 0 {
+			return fmt.Errorf("async response threads must be positive, got %d", threads)
+		}
+
+		o.cliArgs = setCLIArg(o.cliArgs, "async-response-enabled", "true")
+		o.cliArgs = setCLIArg(o.cliArgs, "async-response-threads", strconv.Itoa(threads))
+
+		return nil
+	}
+}
+
+// WithMaxRequestJournalEntries limits the number of requests kept in the request journal
+func WithMaxRequestJournalEntries(entries int) Option {
+	return withPositiveCLIArg("max-request-journal-entries", entries)
+}
+
+// WithContainerThreads sets the number of threads of the Jetty thread pool
+func WithContainerThreads(threads int) Option {
+	return withPositiveCLIArg("container-threads", threads)
+}
+
+// WithJettyAcceptors sets the number of Jetty acceptor threads
+func WithJettyAcceptors(threads int) Option {
+	return withPositiveCLIArg("jetty-acceptor-threads", threads)
+}
+
+// WithNoRequestJournal disables the request journal
+func WithNoRequestJournal() Option {
+	return withCLIArg("no-request-journal", "")
+}
+
+func withCLIArg(name string, value string) Option {
+	return func(o *options) error {
+		o.cliArgs = setCLIArg(o.cliArgs, name, value)
+
+		return nil
+	}
+}
+
+func withPositiveCLIArg(name string, value int) Option {
+	return func(o *options) error {
+		if value <= 0 {
+			return fmt.Errorf("--%s must be positive, got %d", name, value)
+		}
+
+		o.cliArgs = setCLIArg(o.cliArgs, name, strconv.Itoa(value))
+
+		return nil
+	}
+}
+
+// buildCommand merges the command set on the container request by other customizers
+// with the module options and renders the final WireMock command line
+func buildCommand(cmd []string, args []cliArg) ([]string, error) {
+	parsed, err := parseCLIArgs(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("container command: %w", err)
+	}
+
+	merged := slices.Clone(defaultCLIArgs)
+	for _, arg := range slices.Concat(parsed, args) {
+		merged = setCLIArg(merged, arg.name, arg.value)
+	}
+
+	if err := validateCLIArgs(merged); err != nil {
+		return nil, err
+	}
+
+	rendered := make([]string, 0, 2*len(merged))
+	for _, arg := range merged {
+		rendered = append(rendered, "--"+arg.name)
+		if arg.value != "" {
+			rendered = append(rendered, arg.value)
+		}
+	}
+
+	return rendered, nil
+}
+
+func validateCLIArgs(args []cliArg) error {
+	has := func(name string) bool {
+		return slices.ContainsFunc(args, func(arg cliArg) bool { return arg.name == name })
+	}
+
+	if has("no-request-journal") && has("max-request-journal-entries") {
+		return errors.New("--no-request-journal cannot be combined with --max-request-journal-entries")
+	}
+
+	return nil
+}
+
+// parseCLIArgs parses options passed as "--name", "--name=value" or "--name value"
+func parseCLIArgs(tokens []string) ([]cliArg, error) {
+	var args []cliArg
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(token, "--") || len(token) == len("--") {
+			return nil, fmt.Errorf("invalid WireMock CLI argument %q, expected --name, --name=value or --name value", token)
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(token, "--"), "=")
+		if !hasValue && i+1 < len(tokens) && tokens[i+1] != "" && !strings.HasPrefix(tokens[i+1], "--") {
+			i++
+			value = tokens[i]
+		}
+
+		args = setCLIArg(args, name, value)
+	}
+
+	return args, nil
+}
+
+// setCLIArg adds the option or overrides its value, keeping the position of its first occurrence
+func setCLIArg(args []cliArg, name string, value string) []cliArg {
+	i := slices.IndexFunc(args, func(arg cliArg) bool { return arg.name == name })
+	if i < 0 {
+		return append(args, cliArg{name: name, value: value})
+	}
+
+	if slices.Contains(listCLIArgs, name) {
+		values := strings.Split(args[i].value, ",")
+		for _, v := range strings.Split(value, ",") {
+			if !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+		args[i].value = strings.Join(values, ",")
+	} else {
+		args[i].value = value
+	}
+
+	return args
+}
@@ -0,0 +1,149 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestCLIOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []testcontainers.ContainerCustomizer
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"--disable-banner"},
+		},
+		{
+			name: "typed options",
+			opts: []testcontainers.ContainerCustomizer{
+				WithGlobalResponseTemplating(),
+				WithVerbose(),
+				WithAsyncResponses(4),
+				WithMaxRequestJournalEntries(100),
+				WithContainerThreads(50),
+				WithJettyAcceptors(2),
+			},
+			expected: []string{
+				"--disable-banner",
+				"--global-response-templating",
+				"--verbose",
+				"--async-response-enabled", "true",
+				"--async-response-threads", "4",
+				"--max-request-journal-entries", "100",
+				"--container-threads", "50",
+				"--jetty-acceptor-threads", "2",
+			},
+		},
+		{
+			name: "duplicated options keep the last value",
+			opts: []testcontainers.ContainerCustomizer{
+				WithVerbose(),
+				WithContainerThreads(10),
+				WithCLIArgs("--container-threads=20", "--verbose"),
+			},
+			expected: []string{"--disable-banner", "--verbose", "--container-threads", "20"},
+		},
+		{
+			name: "raw arguments",
+			opts: []testcontainers.ContainerCustomizer{
+				WithCLIArgs("--no-request-journal", "--local-response-templating", "--max-template-cache-entries", "10"),
+			},
+			expected: []string{
+				"--disable-banner",
+				"--no-request-journal",
+				"--local-response-templating",
+				"--max-template-cache-entries", "10",
+			},
+		},
+		{
+			name: "command set by other customizers is merged",
+			opts: []testcontainers.ContainerCustomizer{
+				testcontainers.WithCmdArgs("--verbose", "--disable-banner"),
+				WithNoRequestJournal(),
+			},
+			expected: []string{"--disable-banner", "--verbose", "--no-request-journal"},
+		},
+		{
+			name: "extensions are merged",
+			opts: []testcontainers.ContainerCustomizer{
+				WithExtension("first", "org.example.First", "first.jar"),
+				WithCLIArgs("--extensions", "org.example.Second,org.example.First"),
+			},
+			expected: []string{"--disable-banner", "--extensions", "org.example.Second,org.example.First"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _, err := newContainerRequest(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(req.Cmd, tt.expected) {
+				t.Fatalf("expected command %v but got %v", tt.expected, req.Cmd)
+			}
+		})
+	}
+}
+
+func TestCLIOptionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []testcontainers.ContainerCustomizer
+	}{
+		{name: "async response threads", opts: []testcontainers.ContainerCustomizer{WithAsyncResponses(0)}},
+		{name: "max request journal entries", opts: []testcontainers.ContainerCustomizer{WithMaxRequestJournalEntries(-1)}},
+		{name: "container threads", opts: []testcontainers.ContainerCustomizer{WithContainerThreads(0)}},
+		{name: "jetty acceptors", opts: []testcontainers.ContainerCustomizer{WithJettyAcceptors(-2)}},
+		{name: "raw argument without dashes", opts: []testcontainers.ContainerCustomizer{WithCLIArgs("verbose")}},
+		{
+			name: "conflicting journal options",
+			opts: []testcontainers.ContainerCustomizer{WithNoRequestJournal(), WithMaxRequestJournalEntries(10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := newContainerRequest(tt.opts...); err == nil {
+				t.Fatal("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestWireMockWithGlobalResponseTemplating(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t, WithGlobalResponseTemplating())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = container.Client.StubFor(
+		wiremock.Get(wiremock.URLPathEqualTo("/echo")).
+			WillReturnResponse(wiremock.NewResponse().WithBody("{{request.query.name}}").WithStatus(http.StatusOK)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, out, err := SendHttpGet(container, "/echo", map[string]string{"name": "WireMock"})
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if strings.TrimSpace(out) != "WireMock" {
+		t.Fatalf("expected 'WireMock' but got %s", out)
+	}
+}
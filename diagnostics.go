@@ -0,0 +1,210 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxDiagnosticsBodyLength is the number of body bytes printed for each unmatched request
+const maxDiagnosticsBodyLength = 512
+
+// nearMiss is a stub mapping, or a request pattern, almost matching a logged request
+type nearMiss struct {
+	Request        LoggedRequest `json:"request"`
+	StubMapping    *StubMapping  `json:"stubMapping,omitempty"`
+	RequestPattern *StubRequest  `json:"requestPattern,omitempty"`
+	MatchResult    struct {
+		Distance float64 `json:"distance"`
+	} `json:"matchResult"`
+}
+
+type nearMissesResponse struct {
+	NearMisses []nearMiss `json:"nearMisses"`
+}
+
+type loggedRequestsResponse struct {
+	Requests []LoggedRequest `json:"requests"`
+}
+
+// Diagnostics returns a readable report of the request journal, the unmatched requests
+// and the stubs closest to them, telling which matchers differed
+func (c *WireMockContainer) Diagnostics(ctx context.Context) string {
+	var report strings.Builder
+	report.WriteString("WireMock diagnostics\n")
+
+	events, err := c.Requests(ctx, RequestFilter{})
+	if err != nil {
+		fmt.Fprintf(&report, "Request journal unavailable: %s\n", err)
+	} else {
+		fmt.Fprintf(&report, "Request journal (%d requests):\n", len(events))
+		for _, event := range events {
+			fmt.Fprintf(&report, "  %s %s -> %d", event.Request.Method, event.Request.URL, event.Response.Status)
+			if !event.WasMatched {
+				report.WriteString(" (unmatched)")
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	var unmatched loggedRequestsResponse
+	if err := c.adminRequest(ctx, http.MethodGet, "/requests/unmatched", nil, &unmatched); err != nil {
+		fmt.Fprintf(&report, "Unmatched requests unavailable: %s\n", err)
+		return report.String()
+	}
+
+	var nearMisses nearMissesResponse
+	if err := c.adminRequest(ctx, http.MethodGet, "/requests/unmatched/near-misses", nil, &nearMisses); err != nil {
+		fmt.Fprintf(&report, "Near misses unavailable: %s\n", err)
+	}
+	closest := make(map[string]nearMiss)
+	for _, miss := range nearMisses.NearMisses {
+		key := miss.Request.key()
+		if current, ok := closest[key]; miss.StubMapping != nil && (!ok || miss.MatchResult.Distance < current.MatchResult.Distance) {
+			closest[key] = miss
+		}
+	}
+
+	fmt.Fprintf(&report, "Unmatched requests (%d):\n", len(unmatched.Requests))
+	for _, request := range unmatched.Requests {
+		writeLoggedRequest(&report, request)
+
+		miss, ok := closest[request.key()]
+		if !ok {
+			report.WriteString("    No close stub\n")
+			continue
+		}
+		fmt.Fprintf(&report, "    Closest stub: %s (distance %.2f)\n", stubLabel(miss.StubMapping), miss.MatchResult.Distance)
+		for _, difference := range requestDifferences(request, miss.StubMapping.Request) {
+			fmt.Fprintf(&report, "      %s\n", difference)
+		}
+	}
+
+	return report.String()
+}
+
+func writeLoggedRequest(report *strings.Builder, request LoggedRequest) {
+	fmt.Fprintf(report, "  %s %s\n", request.Method, request.URL)
+
+	for _, name := range slices.Sorted(maps.Keys(request.Headers)) {
+		fmt.Fprintf(report, "    %s: %s\n", name, strings.Join(request.Headers[name], ", "))
+	}
+
+	if len(request.Body) > 0 {
+		body := request.Body.String()
+		if len(body) > maxDiagnosticsBodyLength {
+			body = body[:maxDiagnosticsBodyLength] + "... (truncated)"
+		}
+		fmt.Fprintf(report, "    Body: %s\n", body)
+	}
+}
+
+func stubLabel(stub *StubMapping) string {
+	switch {
+	case stub.Name != "":
+		return stub.Name
+	case stub.ID != "":
+		return stub.ID
+	default:
+		return stub.UUID
+	}
+}
+
+// requestDifferences tells which of the method, URL and header matchers of the pattern the request does not satisfy.
+// The other matchers, such as the body patterns, are left to WireMock.
+func requestDifferences(request LoggedRequest, pattern StubRequest) []string {
+	var differences []string
+
+	if pattern.Method != "" && pattern.Method != "ANY" && pattern.Method != request.Method {
+		differences = append(differences, fmt.Sprintf("method: expected %s but was %s", pattern.Method, request.Method))
+	}
+
+	path, _, _ := strings.Cut(request.URL, "?")
+	switch {
+	case pattern.URL != "" && pattern.URL != request.URL:
+		differences = append(differences, fmt.Sprintf("url: expected %q but was %q", pattern.URL, request.URL))
+	case pattern.URLPath != "" && pattern.URLPath != path:
+		differences = append(differences, fmt.Sprintf("urlPath: expected %q but was %q", pattern.URLPath, path))
+	case pattern.URLPattern != "" && !fullMatch(pattern.URLPattern, request.URL):
+		differences = append(differences, fmt.Sprintf("urlPattern: expected %q to match %q", request.URL, pattern.URLPattern))
+	case pattern.URLPathPattern != "" && !fullMatch(pattern.URLPathPattern, path):
+		differences = append(differences, fmt.Sprintf("urlPathPattern: expected %q to match %q", path, pattern.URLPathPattern))
+	case pattern.URLPathTemplate != "" && !pathTemplateRegexp(pattern.URLPathTemplate).MatchString(path):
+		differences = append(differences, fmt.Sprintf("urlPathTemplate: expected %q to match %q", path, pattern.URLPathTemplate))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pattern.Headers)) {
+		if difference := headerDifference(request.Headers, name, pattern.Headers[name]); difference != "" {
+			differences = append(differences, difference)
+		}
+	}
+
+	if len(differences) == 0 {
+		differences = append(differences, "method, URL and headers match, another matcher such as the query parameters or the body differed")
+	}
+
+	return differences
+}
+
+func headerDifference(headers http.Header, name string, matcher map[string]any) string {
+	value := headers.Get(name)
+	present := len(headers.Values(name)) > 0
+
+	if absent, _ := matcher["absent"].(bool); absent {
+		if present {
+			return fmt.Sprintf("header %s: expected absent but was %q", name, value)
+		}
+		return ""
+	}
+
+	for operator, expected := range matcher {
+		expectedValue, ok := expected.(string)
+		if !ok {
+			continue
+		}
+
+		var matches bool
+		switch operator {
+		case "equalTo":
+			if caseInsensitive, _ := matcher["caseInsensitive"].(bool); caseInsensitive {
+				matches = strings.EqualFold(value, expectedValue)
+			} else {
+				matches = value == expectedValue
+			}
+		case "contains":
+			matches = strings.Contains(value, expectedValue)
+		case "matches":
+			matches = fullMatch(expectedValue, value)
+		case "doesNotMatch":
+			matches = !fullMatch(expectedValue, value)
+		default:
+			continue
+		}
+
+		switch {
+		case !present:
+			return fmt.Sprintf("header %s: expected %s %q but was absent", name, operator, expectedValue)
+		case !matches:
+			return fmt.Sprintf("header %s: expected %s %q but was %q", name, operator, expectedValue, value)
+		}
+	}
+
+	return ""
+}
+
+// fullMatch reports whether the whole value matches the pattern, as Java's String.matches does
+func fullMatch(pattern string, value string) bool {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	return err == nil && re.MatchString(value)
+}
+
+var pathTemplateVariable = regexp.MustCompile(`\\\{[^/]+?\\\}`)
+
+// pathTemplateRegexp turns a URL path template such as /users/{id} into a regular expression
+func pathTemplateRegexp(template string) *regexp.Regexp {
+	return regexp.MustCompile("^" + pathTemplateVariable.ReplaceAllString(regexp.QuoteMeta(template), "[^/]+") + "$")
+}
@@ -0,0 +1,133 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/requests":
+			_, _ = w.Write([]byte(`{"requests": [
+				{"id": "2", "request": {"method": "POST", "url": "/orders"}, "response": {"status": 404}, "wasMatched": false},
+				{"id": "1", "request": {"method": "GET", "url": "/hello"}, "response": {"status": 200}, "wasMatched": true}
+			]}`))
+		case "/__admin/requests/unmatched":
+			_, _ = w.Write([]byte(`{"requests": [
+				{"method": "POST", "url": "/orders", "absoluteUrl": "http://localhost/orders", "loggedDate": 1,
+				 "headers": {"Content-Type": "text/plain"}, "body": "` + strings.Repeat("x", 600) + `"}
+			]}`))
+		case "/__admin/requests/unmatched/near-misses":
+			_, _ = w.Write([]byte(`{"nearMisses": [
+				{"request": {"method": "POST", "url": "/orders", "absoluteUrl": "http://localhost/orders", "loggedDate": 1,
+				  "headers": {"Content-Type": "text/plain"}, "body": "` + strings.Repeat("x", 600) + `"},
+				 "stubMapping": {"name": "far", "request": {"method": "GET", "url": "/other"}}, "matchResult": {"distance": 0.8}},
+				{"request": {"method": "POST", "url": "/orders", "absoluteUrl": "http://localhost/orders", "loggedDate": 1,
+				  "headers": {"Content-Type": "text/plain"}, "body": "` + strings.Repeat("x", 600) + `"},
+				 "stubMapping": {"name": "create order", "request": {"method": "PUT", "url": "/orders",
+				  "headers": {"Content-Type": {"equalTo": "application/json"}}}}, "matchResult": {"distance": 0.2}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	report := container.Diagnostics(context.Background())
+
+	for _, expected := range []string{
+		"Request journal (2 requests):\n  GET /hello -> 200\n  POST /orders -> 404 (unmatched)\n",
+		"Unmatched requests (1):\n  POST /orders\n    Content-Type: text/plain\n",
+		"    Body: " + strings.Repeat("x", maxDiagnosticsBodyLength) + "... (truncated)\n",
+		"    Closest stub: create order (distance 0.20)\n",
+		"      method: expected PUT but was POST\n",
+		`      header Content-Type: expected equalTo "application/json" but was "text/plain"` + "\n",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Fatalf("expected the report to contain %q but got:\n%s", expected, report)
+		}
+	}
+}
+
+func TestRequestDifferences(t *testing.T) {
+	request := LoggedRequest{
+		Method:  http.MethodGet,
+		URL:     "/users/42?verbose=true",
+		Headers: http.Header{"Accept": {"application/xml"}},
+	}
+
+	testCases := []struct {
+		name     string
+		pattern  StubRequest
+		expected []string
+	}{
+		{
+			name:     "url",
+			pattern:  StubRequest{Method: "ANY", URL: "/users/42"},
+			expected: []string{`url: expected "/users/42" but was "/users/42?verbose=true"`},
+		},
+		{
+			name:     "url path template",
+			pattern:  StubRequest{URLPathTemplate: "/users/{id}/orders"},
+			expected: []string{`urlPathTemplate: expected "/users/42" to match "/users/{id}/orders"`},
+		},
+		{
+			name:     "url path pattern",
+			pattern:  StubRequest{URLPathPattern: "/users/[a-z]+"},
+			expected: []string{`urlPathPattern: expected "/users/42" to match "/users/[a-z]+"`},
+		},
+		{
+			name: "headers",
+			pattern: StubRequest{URLPath: "/users/42", Headers: map[string]map[string]any{
+				"Accept":        {"contains": "json"},
+				"Authorization": {"matches": "Bearer .+"},
+				"X-Debug":       {"absent": true},
+			}},
+			expected: []string{
+				`header Accept: expected contains "json" but was "application/xml"`,
+				`header Authorization: expected matches "Bearer .+" but was absent`,
+			},
+		},
+		{
+			name:     "other matchers",
+			pattern:  StubRequest{Method: http.MethodGet, URLPathTemplate: "/users/{id}"},
+			expected: []string{"method, URL and headers match, another matcher such as the query parameters or the body differed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			differences := requestDifferences(request, tc.pattern)
+			if !slices.Equal(differences, tc.expected) {
+				t.Fatalf("expected differences %q but got %q", tc.expected, differences)
+			}
+		})
+	}
+}
+
+func TestWireMockDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, _, err := SendHttpPost(container, "/hello", strings.NewReader("Hi!"))
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 404 {
+		t.Fatalf("expected HTTP-404 but got %d", statusCode)
+	}
+
+	report := container.Diagnostics(ctx)
+	if !strings.Contains(report, "method: expected GET but was POST") {
+		t.Fatalf("expected the report to tell the method differed but got:\n%s", report)
+	}
+}
@@ -0,0 +1,107 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+const (
+	minPollInterval = 50 * time.Millisecond
+	maxPollInterval = time.Second
+	// describeTimeout bounds the requests made to describe the journal once the wait has failed
+	describeTimeout = 5 * time.Second
+)
+
+// WaitForRequest polls the request journal, with an exponential backoff, until at least count requests
+// match the pattern, and returns their serve events. When ctx is done first, the error describes
+// the requests received instead. A context without deadline waits forever.
+func (c *WireMockContainer) WaitForRequest(ctx context.Context, r *wiremock.Request, count int) ([]ServeEvent, error) {
+	interval := minPollInterval
+	actual := 0
+	for {
+		var res struct {
+			Count int `json:"count"`
+		}
+		err := c.adminRequest(ctx, http.MethodPost, "/requests/count", r, &res)
+		switch {
+		case err == nil:
+			actual = res.Count
+			if actual >= count {
+				return c.matchingEvents(ctx, r)
+			}
+		case ctx.Err() == nil:
+			return nil, fmt.Errorf("wait for request: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, c.waitError(ctx.Err(), r, count, actual)
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+}
+
+// AssertEventuallyCalled waits up to timeout for at least times requests matching the pattern.
+// On failure, it reports the error with the requests received instead.
+func (c *WireMockContainer) AssertEventuallyCalled(t testing.TB, r *wiremock.Request, times int, timeout time.Duration) []ServeEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	events, err := c.WaitForRequest(ctx, r, times)
+	if err != nil {
+		t.Error(err)
+	}
+
+	return events
+}
+
+// matchingEvents returns the serve events of the requests matching the pattern
+func (c *WireMockContainer) matchingEvents(ctx context.Context, r *wiremock.Request) ([]ServeEvent, error) {
+	found, err := c.findRequests(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("wait for request: %w", err)
+	}
+	matching := tallyRequests(found)
+
+	events, err := c.Requests(ctx, RequestFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("wait for request: %w", err)
+	}
+
+	var matched []ServeEvent
+	for _, event := range events {
+		if matching.take(event.Request) {
+			matched = append(matched, event)
+		}
+	}
+
+	return matched, nil
+}
+
+func (c *WireMockContainer) waitError(cause error, r *wiremock.Request, count int, actual int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "expected at least %d requests matching\n  %s\nbut got %d\n", count, patternJSON(r), actual)
+	if events, err := c.Requests(ctx, RequestFilter{}); err != nil {
+		fmt.Fprintf(&message, "Received requests unavailable: %s\n", err)
+	} else {
+		fmt.Fprintf(&message, "Received requests (%d):\n", len(events))
+		for _, event := range events {
+			fmt.Fprintf(&message, "  %s %s -> %d\n", event.Request.Method, event.Request.URL, event.Response.Status)
+		}
+	}
+	c.writeClosestRequests(ctx, &message, r)
+
+	return fmt.Errorf("wait for request: %w\n%s", cause, message.String())
+}
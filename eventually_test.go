@@ -0,0 +1,135 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestWaitForRequest(t *testing.T) {
+	var polls atomic.Int32
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		hello := `{"method": "GET", "url": "/hello", "absoluteUrl": "http://localhost/hello", "loggedDate": 1}`
+		switch r.URL.Path {
+		case "/__admin/requests/count":
+			if polls.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"count": 0}`))
+			} else {
+				_, _ = w.Write([]byte(`{"count": 1}`))
+			}
+		case "/__admin/requests/find":
+			_, _ = w.Write([]byte(`{"requests": [` + hello + `]}`))
+		case "/__admin/requests":
+			_, _ = w.Write([]byte(`{"requests": [
+				{"id": "2", "request": {"method": "GET", "url": "/other", "loggedDate": 2}, "response": {"status": 404}},
+				{"id": "1", "request": ` + hello + `, "response": {"status": 200}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := container.WaitForRequest(ctx, wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/hello")), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls.Load() != 3 {
+		t.Fatalf("expected 3 polls but got %d", polls.Load())
+	}
+	if len(events) != 1 || events[0].ID != "1" {
+		t.Fatalf("expected the serve event of GET /hello but got %+v", events)
+	}
+}
+
+func TestWaitForRequestSameMillisecond(t *testing.T) {
+	// Two orders received within the same millisecond, only the first matches the pattern
+	orderA := `{"method": "POST", "url": "/orders", "absoluteUrl": "http://localhost/orders", "loggedDate": 5, "body": "A"}`
+	orderB := `{"method": "POST", "url": "/orders", "absoluteUrl": "http://localhost/orders", "loggedDate": 5, "body": "B"}`
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/requests/count":
+			_, _ = w.Write([]byte(`{"count": 1}`))
+		case "/__admin/requests/find":
+			_, _ = w.Write([]byte(`{"requests": [` + orderA + `]}`))
+		case "/__admin/requests":
+			_, _ = w.Write([]byte(`{"requests": [{"id": "b", "request": ` + orderB + `}, {"id": "a", "request": ` + orderA + `}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pattern := wiremock.NewRequest(http.MethodPost, wiremock.URLEqualTo("/orders")).WithBodyPattern(wiremock.EqualTo("A"))
+	events, err := container.WaitForRequest(ctx, pattern, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != "a" {
+		t.Fatalf("expected only the serve event of the matching order but got %+v", events)
+	}
+}
+
+func TestWaitForRequestTimeout(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/requests/count":
+			_, _ = w.Write([]byte(`{"count": 1}`))
+		case "/__admin/requests":
+			_, _ = w.Write([]byte(`{"requests": [{"id": "1", "request": {"method": "POST", "url": "/hello"}, "response": {"status": 404}}]}`))
+		case "/__admin/near-misses/request-pattern":
+			_, _ = w.Write([]byte(`{"nearMisses": [{"request": {"method": "POST", "url": "/hello"},
+				"requestPattern": {"method": "GET", "url": "/hello"}, "matchResult": {"distance": 0.1}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := container.WaitForRequest(ctx, wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/hello")), 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to time out but got %v", err)
+	}
+
+	for _, expected := range []string{
+		"expected at least 2 requests matching\n",
+		"but got 1\nReceived requests (1):\n  POST /hello -> 404\n",
+		"Closest requests:\n  POST /hello (distance 0.10)\n    method: expected GET but was POST\n",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error to contain %q but got %q", expected, err)
+		}
+	}
+}
+
+func TestWireMockWaitForRequest(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		if _, _, err := SendHttpGet(container, "/hello", nil); err != nil {
+			t.Error(err, "Failed to get a response")
+		}
+	}()
+
+	events := container.AssertEventuallyCalled(t, wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/hello")), 1, 10*time.Second)
+	if len(events) != 1 || events[0].Response.Body.String() != "Hello, world!" {
+		t.Fatalf("expected the serve event of GET /hello but got %+v", events)
+	}
+}
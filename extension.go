@@ -0,0 +1,64 @@
+package testcontainers_wiremock
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+)
+
+const extensionsDir = "/var/wiremock/extensions"
+
+// WireMockExtension describes a WireMock extension loaded into the container from a JAR file
+type WireMockExtension struct {
+	id        string
+	classname string
+	jarPath   string
+}
+
+// ID returns the identifier the extension was registered with
+func (e WireMockExtension) ID() string {
+	return e.id
+}
+
+// ClassName returns the fully qualified name of the extension class passed to --extensions
+func (e WireMockExtension) ClassName() string {
+	return e.classname
+}
+
+// JarPath returns the host path of the JAR file providing the extension
+func (e WireMockExtension) JarPath() string {
+	return e.jarPath
+}
+
+func (e WireMockExtension) containerFilePath() string {
+	return path.Join(extensionsDir, e.id+".jar")
+}
+
+// WithExtension copies the JAR file into the WireMock extensions directory
+// and registers the extension class with the --extensions CLI option.
+// It can be passed several times to load multiple extensions, each one needs a unique id.
+func WithExtension(id string, classname string, jarPath string) Option {
+	return func(o *options) error {
+		if id == "" || classname == "" || jarPath == "" {
+			return errors.New("extension id, classname and jar path must not be empty")
+		}
+
+		if slices.ContainsFunc(o.extensions, func(e WireMockExtension) bool { return e.id == id }) {
+			return fmt.Errorf("extension with id %q is already registered", id)
+		}
+
+		o.extensions = append(o.extensions, WireMockExtension{
+			id:        id,
+			classname: classname,
+			jarPath:   jarPath,
+		})
+
+		return nil
+	}
+}
+
+// Extensions returns the extensions loaded into the container
+func (c *WireMockContainer) Extensions() []WireMockExtension {
+	return slices.Clone(c.extensions)
+}
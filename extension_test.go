@@ -0,0 +1,57 @@
+package testcontainers_wiremock
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWithExtension(t *testing.T) {
+	req, settings, err := newContainerRequest(
+		WithExtension("headers", "org.example.HeadersTransformer", filepath.Join("testdata", "headers.jar")),
+		WithExtension("matcher", "org.example.CustomMatcher", filepath.Join("testdata", "matcher.jar")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(settings.extensions) != 2 {
+		t.Fatalf("expected 2 extensions but got %d", len(settings.extensions))
+	}
+
+	files := map[string]string{}
+	for _, f := range req.Files {
+		files[f.ContainerFilePath] = f.HostFilePath
+	}
+	if files["/var/wiremock/extensions/headers.jar"] != filepath.Join("testdata", "headers.jar") {
+		t.Fatalf("expected headers.jar to be copied into the extensions directory but got %v", files)
+	}
+	if files["/var/wiremock/extensions/matcher.jar"] != filepath.Join("testdata", "matcher.jar") {
+		t.Fatalf("expected matcher.jar to be copied into the extensions directory but got %v", files)
+	}
+
+	i := slices.Index(req.Cmd, "--extensions")
+	if i < 0 || i+1 >= len(req.Cmd) {
+		t.Fatalf("expected --extensions in the command but got %v", req.Cmd)
+	}
+	if req.Cmd[i+1] != "org.example.HeadersTransformer,org.example.CustomMatcher" {
+		t.Fatalf("expected both extension classes but got %s", req.Cmd[i+1])
+	}
+}
+
+func TestWithExtensionRejectsDuplicateID(t *testing.T) {
+	_, _, err := newContainerRequest(
+		WithExtension("ext", "org.example.First", "first.jar"),
+		WithExtension("ext", "org.example.Second", "second.jar"),
+	)
+	if err == nil {
+		t.Fatal("expected an error for a duplicate extension id")
+	}
+}
+
+func TestWithExtensionRejectsEmptyArguments(t *testing.T) {
+	_, _, err := newContainerRequest(WithExtension("ext", "", "ext.jar"))
+	if err == nil {
+		t.Fatal("expected an error for an empty classname")
+	}
+}
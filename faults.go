@@ -0,0 +1,79 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// SetGlobalDelay adds a fixed delay to all the responses, 0 removes it.
+// WireMock counts delays in milliseconds, so the delay is rounded up to the next millisecond.
+func (c *WireMockContainer) SetGlobalDelay(ctx context.Context, delay time.Duration) error {
+	if delay < 0 {
+		return fmt.Errorf("set global delay: the delay must not be negative, got %s", delay)
+	}
+
+	return c.updateSettings(ctx, "set global delay", func(settings map[string]any) {
+		if delay == 0 {
+			delete(settings, "fixedDelay")
+			return
+		}
+		settings["fixedDelay"] = (delay + time.Millisecond - 1).Milliseconds()
+	})
+}
+
+// SetGlobalDelayDistribution adds a random delay to all the responses, e.g. wiremock.NewUniformRandomDelay
+// or wiremock.NewLogNormalRandomDelay, nil removes it
+func (c *WireMockContainer) SetGlobalDelayDistribution(ctx context.Context, delay wiremock.DelayInterface) error {
+	return c.updateSettings(ctx, "set global delay distribution", func(settings map[string]any) {
+		if delay == nil {
+			delete(settings, "delayDistribution")
+			return
+		}
+		settings["delayDistribution"] = delay.ParseDelay()
+	})
+}
+
+// ClearGlobalDelays removes the delays set by SetGlobalDelay and SetGlobalDelayDistribution
+func (c *WireMockContainer) ClearGlobalDelays(ctx context.Context) error {
+	return c.updateSettings(ctx, "clear global delays", func(settings map[string]any) {
+		delete(settings, "fixedDelay")
+		delete(settings, "delayDistribution")
+	})
+}
+
+// StubFault registers the stub answering with the fault instead of a response,
+// e.g. wiremock.FaultConnectionResetByPeer or wiremock.FaultMalformedResponseChunk
+func (c *WireMockContainer) StubFault(ctx context.Context, stub *wiremock.StubRule, fault wiremock.Fault) error {
+	stub.WillReturnResponse(wiremock.NewResponse().WithFault(fault))
+
+	if err := c.adminRequest(ctx, http.MethodPost, "/mappings", stub, nil); err != nil {
+		return fmt.Errorf("stub fault: %w", err)
+	}
+
+	return nil
+}
+
+// updateSettings applies the update to the global settings, as posting them replaces all of them
+func (c *WireMockContainer) updateSettings(ctx context.Context, operation string, update func(settings map[string]any)) error {
+	var res struct {
+		Settings map[string]any `json:"settings"`
+	}
+	if err := c.adminRequest(ctx, http.MethodGet, "/settings", nil, &res); err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	if res.Settings == nil {
+		res.Settings = make(map[string]any)
+	}
+
+	update(res.Settings)
+
+	if err := c.adminRequest(ctx, http.MethodPost, "/settings", res.Settings, nil); err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return nil
+}
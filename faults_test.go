@@ -0,0 +1,112 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestGlobalDelays(t *testing.T) {
+	settings := map[string]any{"proxyPassThrough": true}
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"settings": settings})
+		case http.MethodPost:
+			settings = nil
+			if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	ctx := context.Background()
+
+	if err := container.SetGlobalDelay(ctx, 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := container.SetGlobalDelayDistribution(ctx, wiremock.NewUniformRandomDelay(100*time.Millisecond, 300*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	if settings["proxyPassThrough"] != true || settings["fixedDelay"] != 1500.0 {
+		t.Fatalf("expected the fixed delay to be added to the settings but got %v", settings)
+	}
+	distribution, _ := settings["delayDistribution"].(map[string]any)
+	if distribution["type"] != "uniform" || distribution["lower"] != 100.0 || distribution["upper"] != 300.0 {
+		t.Fatalf("unexpected delay distribution %v", settings["delayDistribution"])
+	}
+
+	if err := container.ClearGlobalDelays(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(settings) != 1 || settings["proxyPassThrough"] != true {
+		t.Fatalf("expected the delays to be removed but got %v", settings)
+	}
+	if err := container.SetGlobalDelay(ctx, 100*time.Microsecond); err != nil {
+		t.Fatal(err)
+	}
+	if settings["fixedDelay"] != 1.0 {
+		t.Fatalf("expected the delay to be rounded up to 1ms but got %v", settings["fixedDelay"])
+	}
+	if err := container.SetGlobalDelay(ctx, -time.Second); err == nil {
+		t.Fatal("expected an error for a negative delay")
+	}
+}
+
+func TestStubFault(t *testing.T) {
+	var mapping StubMapping
+	var raw map[string]any
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "POST /__admin/mappings" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			t.Error(err)
+		}
+		content, _ := json.Marshal(raw)
+		_ = json.Unmarshal(content, &mapping)
+	})
+
+	err := container.StubFault(context.Background(), wiremock.Get(wiremock.URLEqualTo("/reset")), wiremock.FaultConnectionResetByPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mapping.Request.URL != "/reset" || raw["response"].(map[string]any)["fault"] != "CONNECTION_RESET_BY_PEER" {
+		t.Fatalf("unexpected stub %v", raw)
+	}
+}
+
+func TestWireMockFaults(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = container.StubFault(ctx, wiremock.Get(wiremock.URLEqualTo("/reset")), wiremock.FaultConnectionResetByPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := SendHttpGet(container, "/reset", nil); err == nil {
+		t.Fatal("expected the connection to be reset")
+	}
+
+	if err := container.SetGlobalDelay(ctx, 500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, _, err := SendHttpGet(container, "/hello", nil); err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Fatalf("expected the response to be delayed by 500ms but got it after %s", elapsed)
+	}
+}
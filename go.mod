@@ -5,6 +5,7 @@ go 1.25.0
 require (
 	github.com/testcontainers/testcontainers-go v0.42.0
 	github.com/wiremock/go-wiremock v1.16.0
+	software.sslmate.com/src/go-pkcs12 v0.7.3
 )
 
 require (
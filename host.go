@@ -0,0 +1,37 @@
+package testcontainers_wiremock
+
+import (
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// WithHostPortAccess exposes the ports of the host to the container, at testcontainers.HostInternal,
+// so that WireMock can proxy to or call back servers running in the test process, see HostURL
+func WithHostPortAccess(ports ...int) testcontainers.CustomizeRequestOption {
+	return testcontainers.WithHostPortAccess(ports...)
+}
+
+// HostPort returns the port the test server listens on, to be passed to WithHostPortAccess
+func HostPort(server *httptest.Server) int {
+	if addr, ok := server.Listener.Addr().(*net.TCPAddr); ok {
+		return addr.Port
+	}
+
+	return 0
+}
+
+// HostURL returns the URL of the test server as seen from the container, e.g. http://host.testcontainers.internal:41234,
+// for use in proxy stubs and webhook targets. The port must have been exposed with WithHostPortAccess.
+func HostURL(server *httptest.Server) string {
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		return server.URL
+	}
+	u.Host = net.JoinHostPort(testcontainers.HostInternal, strconv.Itoa(HostPort(server)))
+
+	return u.String()
+}
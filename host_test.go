@@ -0,0 +1,63 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestHostURL(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	port := HostPort(server)
+	expected := "http://host.testcontainers.internal:" + strconv.Itoa(port)
+	if actual := HostURL(server); actual != expected {
+		t.Fatalf("expected %s but got %s", expected, actual)
+	}
+
+	req, _, err := newContainerRequest(WithHostPortAccess(port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(req.HostAccessPorts, []int{port}) {
+		t.Fatalf("expected the host port %d to be exposed but got %v", port, req.HostAccessPorts)
+	}
+}
+
+func TestWireMockHostPortAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Hello from the host!"))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithHostPortAccess(HostPort(server)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := map[string]any{
+		"request":  map[string]any{"url": "/proxied"},
+		"response": map[string]any{"proxyBaseUrl": HostURL(server)},
+	}
+	if err := container.adminRequest(ctx, http.MethodPost, "/mappings", proxy, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, out, err := SendHttpGet(container, "/proxied", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if out != "Hello from the host!" {
+		t.Fatalf("expected 'Hello from the host!' but got %s", out)
+	}
+}
@@ -0,0 +1,349 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// journalDateFormat is the ISO 8601 format the admin API expects for the since parameter
+const journalDateFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// ServeEvent is an entry of the request journal: a request received by WireMock and how it was served
+type ServeEvent struct {
+	ID         string         `json:"id"`
+	Request    LoggedRequest  `json:"request"`
+	Response   LoggedResponse `json:"response"`
+	WasMatched bool           `json:"wasMatched"`
+	// StubMapping is the stub which served the request, nil if none matched
+	StubMapping *StubMapping `json:"stubMapping,omitempty"`
+}
+
+// LoggedRequest is a request received by WireMock
+type LoggedRequest struct {
+	Method      string
+	URL         string
+	AbsoluteURL string
+	ClientIP    string
+	Headers     http.Header
+	QueryParams url.Values
+	Body        Body
+	LoggedDate  time.Time
+}
+
+// UnmarshalJSON decodes the request as serialized by the admin API
+func (r *LoggedRequest) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Method       string                    `json:"method"`
+		URL          string                    `json:"url"`
+		AbsoluteURL  string                    `json:"absoluteUrl"`
+		ClientIP     string                    `json:"clientIp"`
+		Headers      map[string]multiValue     `json:"headers"`
+		QueryParams  map[string]queryParameter `json:"queryParams"`
+		Body         string                    `json:"body"`
+		BodyAsBase64 string                    `json:"bodyAsBase64"`
+		LoggedDate   int64                     `json:"loggedDate"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	body, err := decodeBody(raw.Body, raw.BodyAsBase64)
+	if err != nil {
+		return fmt.Errorf("logged request body: %w", err)
+	}
+
+	*r = LoggedRequest{
+		Method:      raw.Method,
+		URL:         raw.URL,
+		AbsoluteURL: raw.AbsoluteURL,
+		ClientIP:    raw.ClientIP,
+		Headers:     headersFromJSON(raw.Headers),
+		Body:        body,
+		LoggedDate:  time.UnixMilli(raw.LoggedDate),
+	}
+	if len(raw.QueryParams) > 0 {
+		r.QueryParams = make(url.Values, len(raw.QueryParams))
+		for name, param := range raw.QueryParams {
+			r.QueryParams[name] = param.Values
+		}
+	}
+
+	return nil
+}
+
+// key groups the requests which cannot be told apart, as logged requests carry no id.
+// It is not unique: identical requests received within the same millisecond share it,
+// so correlating requests by key must count their occurrences.
+func (r LoggedRequest) key() string {
+	hash := fnv.New64a()
+	for _, name := range slices.Sorted(maps.Keys(r.Headers)) {
+		fmt.Fprintf(hash, "%s: %q\n", name, r.Headers[name])
+	}
+	_, _ = hash.Write(r.Body)
+
+	return fmt.Sprintf("%s %s %d %016x", r.Method, r.AbsoluteURL, r.LoggedDate.UnixMilli(), hash.Sum64())
+}
+
+// requestTally counts the logged requests by key, to correlate them one-to-one with the serve events
+type requestTally map[string]int
+
+func tallyRequests(requests []LoggedRequest) requestTally {
+	tally := make(requestTally, len(requests))
+	for _, request := range requests {
+		tally[request.key()]++
+	}
+
+	return tally
+}
+
+// take tells whether the request is among the tallied ones, and consumes one of its occurrences
+func (t requestTally) take(request LoggedRequest) bool {
+	key := request.key()
+	if t[key] == 0 {
+		return false
+	}
+	t[key]--
+
+	return true
+}
+
+// LoggedResponse is the response WireMock sent to a logged request
+type LoggedResponse struct {
+	Status  int
+	Headers http.Header
+	Body    Body
+	// Fault is the fault injected in place of the response, if any
+	Fault string
+}
+
+// UnmarshalJSON decodes the response as serialized by the admin API
+func (r *LoggedResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Status       int                   `json:"status"`
+		Headers      map[string]multiValue `json:"headers"`
+		Body         string                `json:"body"`
+		BodyAsBase64 string                `json:"bodyAsBase64"`
+		Fault        string                `json:"fault"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	body, err := decodeBody(raw.Body, raw.BodyAsBase64)
+	if err != nil {
+		return fmt.Errorf("logged response body: %w", err)
+	}
+
+	*r = LoggedResponse{
+		Status:  raw.Status,
+		Headers: headersFromJSON(raw.Headers),
+		Body:    body,
+		Fault:   raw.Fault,
+	}
+
+	return nil
+}
+
+// Body is the content of a logged request or response, decoded from base64 when needed
+type Body []byte
+
+// String returns the body as text
+func (b Body) String() string {
+	return string(b)
+}
+
+// DecodeJSON unmarshals the JSON body into v
+func (b Body) DecodeJSON(v any) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("decode json body: %w", err)
+	}
+
+	return nil
+}
+
+// RequestFilter selects the serve events returned by Requests, the zero value selects all of them
+type RequestFilter struct {
+	// Method keeps the requests sent with this HTTP method
+	Method string
+	// URL keeps the requests sent to this URL, path and query
+	URL string
+	// URLPath keeps the requests sent to this path, whatever their query
+	URLPath string
+	// Headers keeps the requests carrying these header values
+	Headers map[string]string
+	// Since keeps the requests received after this time
+	Since time.Time
+	// Until keeps the requests received before this time
+	Until time.Time
+	// StubID keeps the requests served by this stub
+	StubID string
+	// Unmatched keeps the requests no stub matched
+	Unmatched bool
+	// After keeps the requests received after the event with this id, which must still be in the journal
+	After string
+	// Limit is the maximum number of events returned, the oldest first, 0 for no limit
+	Limit int
+	// Last keeps only the most recent requests selected by the other criteria, 0 to keep them all
+	Last int
+}
+
+// serverSide tells whether the admin API applies every criterion of the filter,
+// so that it can also limit the number of events it returns
+func (f RequestFilter) serverSide() bool {
+	return f.Method == "" && f.URL == "" && f.URLPath == "" && len(f.Headers) == 0 &&
+		f.Until.IsZero() && !f.Unmatched && f.After == ""
+}
+
+func (f RequestFilter) matches(event ServeEvent) bool {
+	request := event.Request
+	if f.Method != "" && f.Method != request.Method {
+		return false
+	}
+	if f.URL != "" && f.URL != request.URL {
+		return false
+	}
+	if path, _, _ := strings.Cut(request.URL, "?"); f.URLPath != "" && f.URLPath != path {
+		return false
+	}
+	for name, value := range f.Headers {
+		if !slices.Contains(request.Headers.Values(name), value) {
+			return false
+		}
+	}
+	if !f.Since.IsZero() && !request.LoggedDate.After(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !request.LoggedDate.Before(f.Until) {
+		return false
+	}
+	if f.Unmatched && event.WasMatched {
+		return false
+	}
+
+	return true
+}
+
+type serveEventsResponse struct {
+	Requests               []ServeEvent `json:"requests"`
+	RequestJournalDisabled bool         `json:"requestJournalDisabled"`
+}
+
+// Requests returns the serve events selected by the filter, in the order the requests were received.
+// Passing the ID of the last event as the After of the next call, together with Limit, pages through the journal.
+func (c *WireMockContainer) Requests(ctx context.Context, filter RequestFilter) ([]ServeEvent, error) {
+	query := url.Values{}
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.UTC().Format(journalDateFormat))
+	}
+	if filter.StubID != "" {
+		query.Set("matchingStub", filter.StubID)
+	}
+	// The admin API returns the most recent events first
+	if filter.Last > 0 && filter.serverSide() {
+		query.Set("limit", strconv.Itoa(filter.Last))
+	}
+	endpoint := "/requests"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var res serveEventsResponse
+	if err := c.adminRequest(ctx, http.MethodGet, endpoint, nil, &res); err != nil {
+		return nil, fmt.Errorf("requests: %w", err)
+	}
+	if res.RequestJournalDisabled {
+		return nil, errors.New("requests: the request journal is disabled")
+	}
+
+	// The journal lists the most recent requests first
+	recent := res.Requests
+	if filter.After != "" {
+		i := slices.IndexFunc(recent, func(event ServeEvent) bool { return event.ID == filter.After })
+		if i < 0 {
+			return nil, fmt.Errorf("requests: the event %s is not in the journal", filter.After)
+		}
+		recent = recent[:i]
+	}
+
+	var events []ServeEvent
+	for _, event := range recent {
+		if !filter.matches(event) {
+			continue
+		}
+		events = append(events, event)
+		if filter.Last > 0 && len(events) == filter.Last {
+			break
+		}
+	}
+	slices.Reverse(events)
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+
+	return events, nil
+}
+
+// multiValue decodes the header values, serialized as a string when there is only one of them
+type multiValue []string
+
+func (v *multiValue) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*v = multiValue{single}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*v = values
+
+	return nil
+}
+
+type queryParameter struct {
+	Values []string `json:"values"`
+}
+
+func headersFromJSON(raw map[string]multiValue) http.Header {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	headers := make(http.Header, len(raw))
+	for name, values := range raw {
+		for _, value := range values {
+			headers.Add(name, value)
+		}
+	}
+
+	return headers
+}
+
+func decodeBody(body string, bodyAsBase64 string) (Body, error) {
+	if bodyAsBase64 == "" {
+		if body == "" {
+			return nil, nil
+		}
+		return Body(body), nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(bodyAsBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
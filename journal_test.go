@@ -0,0 +1,206 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const journalJSON = `{"requests": [
+	{"id": "3", "request": {"method": "GET", "url": "/hello", "loggedDate": 3000}, "response": {"status": 200}, "wasMatched": true},
+	{"id": "2", "request": {"method": "POST", "url": "/orders?dry-run=true", "loggedDate": 2000,
+	  "headers": {"Content-Type": "application/json", "Accept": ["application/json", "text/plain"]},
+	  "queryParams": {"dry-run": {"key": "dry-run", "values": ["true"]}},
+	  "body": "{\"id\":42}", "bodyAsBase64": "eyJpZCI6NDJ9"},
+	 "response": {"status": 404, "bodyAsBase64": "Tm90IGZvdW5k"}, "wasMatched": false},
+	{"id": "1", "request": {"method": "GET", "url": "/hello", "loggedDate": 1000, "headers": {"X-Trace": "abc"}},
+	 "response": {"status": 200, "headers": {"Content-Type": "text/plain"}, "body": "Hello, world!"}, "wasMatched": true,
+	 "stubMapping": {"id": "hello-stub", "request": {"method": "GET", "url": "/hello"}}}
+]}`
+
+func TestRequests(t *testing.T) {
+	var query string
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(journalJSON))
+	})
+	ctx := context.Background()
+
+	events, err := container.Requests(ctx, RequestFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := func(events []ServeEvent) []string {
+		var ids []string
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+	if !slices.Equal(ids(events), []string{"1", "2", "3"}) {
+		t.Fatalf("expected the events in the order they were received but got %v", ids(events))
+	}
+
+	first, second := events[0], events[1]
+	if first.StubMapping == nil || first.StubMapping.ID != "hello-stub" || second.StubMapping != nil {
+		t.Fatalf("unexpected stub mappings %v and %v", first.StubMapping, second.StubMapping)
+	}
+	if first.Response.Body.String() != "Hello, world!" || first.Response.Headers.Get("Content-Type") != "text/plain" {
+		t.Fatalf("unexpected response %+v", first.Response)
+	}
+	if !first.Request.LoggedDate.Equal(time.UnixMilli(1000)) {
+		t.Fatalf("unexpected logged date %s", first.Request.LoggedDate)
+	}
+	if !slices.Equal(second.Request.Headers.Values("Accept"), []string{"application/json", "text/plain"}) {
+		t.Fatalf("expected the multi-valued header to be decoded but got %v", second.Request.Headers)
+	}
+	if second.Request.QueryParams.Get("dry-run") != "true" {
+		t.Fatalf("unexpected query parameters %v", second.Request.QueryParams)
+	}
+	if second.Response.Body.String() != "Not found" {
+		t.Fatalf("expected the base64 body to be decoded but got %q", second.Response.Body)
+	}
+	var order struct{ ID int }
+	if err := second.Request.Body.DecodeJSON(&order); err != nil || order.ID != 42 {
+		t.Fatalf("expected the JSON body to be decoded but got %+v, %v", order, err)
+	}
+
+	testCases := []struct {
+		name     string
+		filter   RequestFilter
+		query    string
+		expected []string
+	}{
+		{name: "method", filter: RequestFilter{Method: http.MethodGet}, expected: []string{"1", "3"}},
+		{name: "url", filter: RequestFilter{URL: "/orders?dry-run=true"}, expected: []string{"2"}},
+		{name: "url path", filter: RequestFilter{URLPath: "/orders"}, expected: []string{"2"}},
+		{name: "headers", filter: RequestFilter{Headers: map[string]string{"accept": "text/plain"}}, expected: []string{"2"}},
+		{name: "unmatched", filter: RequestFilter{Unmatched: true}, expected: []string{"2"}},
+		{
+			name:     "time window",
+			filter:   RequestFilter{Since: time.UnixMilli(1000), Until: time.UnixMilli(3000)},
+			query:    "since=1970-01-01T00%3A00%3A01.000Z",
+			expected: []string{"2"},
+		},
+		{name: "stub", filter: RequestFilter{StubID: "hello-stub"}, query: "matchingStub=hello-stub", expected: []string{"1", "2", "3"}},
+		{name: "limit", filter: RequestFilter{Method: http.MethodGet, Limit: 1}, expected: []string{"1"}},
+		{name: "after", filter: RequestFilter{After: "1"}, expected: []string{"2", "3"}},
+		{name: "last", filter: RequestFilter{Last: 2}, query: "limit=2", expected: []string{"2", "3"}},
+		{name: "last filtered", filter: RequestFilter{Method: http.MethodGet, Last: 1}, expected: []string{"3"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := container.Requests(ctx, tc.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tc.query {
+				t.Fatalf("expected query %q but got %q", tc.query, query)
+			}
+			if !slices.Equal(ids(events), tc.expected) {
+				t.Fatalf("expected events %v but got %v", tc.expected, ids(events))
+			}
+		})
+	}
+}
+
+func TestRequestsPages(t *testing.T) {
+	// The requests are received within the same millisecond
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"requests": [
+			{"id": "c", "request": {"method": "GET", "url": "/hello", "loggedDate": 1000}},
+			{"id": "b", "request": {"method": "GET", "url": "/hello", "loggedDate": 1000}},
+			{"id": "a", "request": {"method": "GET", "url": "/hello", "loggedDate": 1000}}
+		]}`))
+	})
+	ctx := context.Background()
+
+	var ids []string
+	filter := RequestFilter{Limit: 2}
+	for range 3 {
+		events, err := container.Requests(ctx, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		filter.After = events[len(events)-1].ID
+	}
+	if !slices.Equal(ids, []string{"a", "b", "c"}) {
+		t.Fatalf("expected every event once but got %v", ids)
+	}
+
+	if _, err := container.Requests(ctx, RequestFilter{After: "evicted"}); err == nil {
+		t.Fatal("expected an error for an event no longer in the journal")
+	}
+}
+
+func TestLoggedRequestKey(t *testing.T) {
+	request := LoggedRequest{Method: http.MethodPost, AbsoluteURL: "http://localhost/orders", LoggedDate: time.UnixMilli(5),
+		Headers: http.Header{"Content-Type": {"text/plain"}}, Body: Body("A")}
+	retry := request
+	other := request
+	other.Body = Body("B")
+	traced := request
+	traced.Headers = http.Header{"Content-Type": {"text/plain"}, "X-Trace": {"abc"}}
+
+	if request.key() != retry.key() {
+		t.Fatal("expected identical requests to share their key")
+	}
+	if request.key() == other.key() || request.key() == traced.key() {
+		t.Fatal("expected requests differing by their body or headers to have different keys")
+	}
+}
+
+func TestRequestsJournalDisabled(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"requests": [], "requestJournalDisabled": true}`))
+	})
+
+	_, err := container.Requests(context.Background(), RequestFilter{})
+	if err == nil || !strings.Contains(err.Error(), "disabled") {
+		t.Fatalf("expected an error telling the journal is disabled but got %v", err)
+	}
+}
+
+func TestWireMockRequests(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := SendHttpGet(container, "/hello", nil); err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if _, _, err := SendHttpPost(container, "/orders", strings.NewReader(`{"id":42}`)); err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+
+	events, err := container.Requests(ctx, RequestFilter{Unmatched: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Request.URL != "/orders" || events[0].Response.Status != 404 {
+		t.Fatalf("expected the unmatched POST /orders but got %+v", events)
+	}
+
+	var order struct{ ID int }
+	if err := events[0].Request.Body.DecodeJSON(&order); err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != 42 {
+		t.Fatalf("expected order 42 but got %d", order.ID)
+	}
+}
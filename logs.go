@@ -0,0 +1,92 @@
+package testcontainers_wiremock
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// LogMode tells when a TestLogConsumer forwards the container logs to the test log
+type LogMode int
+
+const (
+	// LogAlways forwards every log line as soon as it is received
+	LogAlways LogMode = iota
+	// LogVerbose forwards the log lines only when the tests run with -v
+	LogVerbose
+	// LogOnFailure buffers the log lines and dumps them at the end of the test, only if it failed
+	LogOnFailure
+)
+
+const logPrefix = "wiremock"
+
+// TestLogConsumer is a testcontainers.LogConsumer forwarding the WireMock stdout and stderr to t.Log.
+// Log lines received once the test has completed are dropped.
+type TestLogConsumer struct {
+	t    testing.TB
+	mode LogMode
+
+	mu     sync.Mutex
+	buffer []string
+	done   bool
+}
+
+// NewTestLogConsumer creates a log consumer forwarding the container logs to t according to mode
+func NewTestLogConsumer(t testing.TB, mode LogMode) *TestLogConsumer {
+	consumer := &TestLogConsumer{t: t, mode: mode}
+
+	// Registered before the container is started, so it runs after the cleanup terminating it
+	t.Cleanup(func() {
+		consumer.mu.Lock()
+		defer consumer.mu.Unlock()
+
+		consumer.done = true
+		if consumer.mode == LogOnFailure && t.Failed() && len(consumer.buffer) > 0 {
+			t.Log("WireMock container logs:\n" + strings.Join(consumer.buffer, "\n"))
+		}
+		consumer.buffer = nil
+	})
+
+	return consumer
+}
+
+// Accept implements testcontainers.LogConsumer
+func (c *TestLogConsumer) Accept(l testcontainers.Log) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		return
+	}
+
+	line := logPrefix + " " + strings.ToLower(l.LogType) + ": " + strings.TrimRight(string(l.Content), "\r\n")
+	switch c.mode {
+	case LogAlways:
+		c.t.Log(line)
+	case LogVerbose:
+		if testing.Verbose() {
+			c.t.Log(line)
+		}
+	case LogOnFailure:
+		c.buffer = append(c.buffer, line)
+	}
+}
+
+// WithTestLogger forwards the container logs to t.Log, prefixed with the stream they come from
+func WithTestLogger(t testing.TB, mode LogMode) testcontainers.CustomizeRequestOption {
+	return WithLogConsumers(NewTestLogConsumer(t, mode))
+}
+
+// WithLogConsumers adds log consumers to the container, keeping the ones already set
+func WithLogConsumers(consumers ...testcontainers.LogConsumer) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if req.LogConsumerCfg == nil {
+			req.LogConsumerCfg = &testcontainers.LogConsumerConfig{}
+		}
+		req.LogConsumerCfg.Consumers = append(req.LogConsumerCfg.Consumers, consumers...)
+
+		return nil
+	}
+}
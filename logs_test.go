@@ -0,0 +1,133 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// recordingTB captures what the helpers write to the test log
+type recordingTB struct {
+	testing.TB
+	logs     []string
+	errors   []string
+	failed   bool
+	cleanups []func()
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Error(args ...any) {
+	r.failed = true
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.Error(fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Log(args ...any) {
+	for _, arg := range args {
+		r.logs = append(r.logs, arg.(string))
+	}
+}
+
+func (r *recordingTB) Failed() bool {
+	return r.failed
+}
+
+func (r *recordingTB) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *recordingTB) cleanup() {
+	for _, f := range slices.Backward(r.cleanups) {
+		f()
+	}
+}
+
+func TestTestLogConsumer(t *testing.T) {
+	stdout := testcontainers.Log{LogType: testcontainers.StdoutLog, Content: []byte("Mapping loaded\n")}
+	stderr := testcontainers.Log{LogType: testcontainers.StderrLog, Content: []byte("Template error\n")}
+
+	t.Run("always", func(t *testing.T) {
+		tb := &recordingTB{}
+		consumer := NewTestLogConsumer(tb, LogAlways)
+		consumer.Accept(stdout)
+		consumer.Accept(stderr)
+		tb.cleanup()
+		consumer.Accept(stdout)
+
+		expected := []string{"wiremock stdout: Mapping loaded", "wiremock stderr: Template error"}
+		if !slices.Equal(tb.logs, expected) {
+			t.Fatalf("expected logs %q but got %q", expected, tb.logs)
+		}
+	})
+
+	t.Run("on failure", func(t *testing.T) {
+		tb := &recordingTB{}
+		consumer := NewTestLogConsumer(tb, LogOnFailure)
+		consumer.Accept(stdout)
+		if len(tb.logs) != 0 {
+			t.Fatalf("expected the logs to be buffered but got %q", tb.logs)
+		}
+		tb.failed = true
+		tb.cleanup()
+
+		if len(tb.logs) != 1 || !strings.HasSuffix(tb.logs[0], "\nwiremock stdout: Mapping loaded") {
+			t.Fatalf("expected the buffered logs to be dumped but got %q", tb.logs)
+		}
+	})
+
+	t.Run("on success", func(t *testing.T) {
+		tb := &recordingTB{}
+		consumer := NewTestLogConsumer(tb, LogOnFailure)
+		consumer.Accept(stdout)
+		tb.cleanup()
+
+		if len(tb.logs) != 0 {
+			t.Fatalf("expected no logs but got %q", tb.logs)
+		}
+	})
+}
+
+func TestWithLogConsumers(t *testing.T) {
+	first := NewTestLogConsumer(t, LogVerbose)
+	second := NewTestLogConsumer(t, LogVerbose)
+
+	req, _, err := newContainerRequest(
+		testcontainers.WithLogConsumerConfig(&testcontainers.LogConsumerConfig{Consumers: []testcontainers.LogConsumer{first}}),
+		WithLogConsumers(second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(req.LogConsumerCfg.Consumers, []testcontainers.LogConsumer{first, second}) {
+		t.Fatalf("expected both consumers but got %v", req.LogConsumerCfg.Consumers)
+	}
+}
+
+func TestWireMockTestLogger(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+		WithTestLogger(t, LogOnFailure),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, _, err := SendHttpGet(container, "/hello", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+}
@@ -0,0 +1,238 @@
+package testcontainers_wiremock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/wiremock/go-wiremock"
+)
+
+const wireMockRootDir = "/home/wiremock"
+
+// wireMockRootSubdirs lists the subdirectories of the WireMock root directory loaded by WithMappingsDir
+var wireMockRootSubdirs = []string{"mappings", "__files"}
+
+// WithMappingsDir copies a host directory laid out like the WireMock root directory,
+// i.e. containing the "mappings" and/or "__files" subdirectories, into the container.
+// The subdirectories are walked recursively and the relative paths are preserved under /home/wiremock.
+func WithMappingsDir(dir string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		found := false
+		for _, subdir := range wireMockRootSubdirs {
+			root := filepath.Join(dir, subdir)
+
+			info, err := os.Stat(root)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("load mappings dir: %w", err)
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("load mappings dir: %s is not a directory", root)
+			}
+			found = true
+
+			err = filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if entry.IsDir() {
+					return nil
+				}
+
+				// Fail early with the offending path rather than when the container is being created
+				f, err := os.Open(filePath)
+				if err != nil {
+					return err
+				}
+				if err := f.Close(); err != nil {
+					return err
+				}
+
+				rel, err := filepath.Rel(dir, filePath)
+				if err != nil {
+					return err
+				}
+
+				req.Files = append(req.Files, testcontainers.ContainerFile{
+					HostFilePath:      filePath,
+					ContainerFilePath: path.Join(wireMockRootDir, filepath.ToSlash(rel)),
+					FileMode:          0755,
+				})
+
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("load mappings dir: %w", err)
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("load mappings dir: %s contains neither a mappings nor a __files directory", dir)
+		}
+
+		return nil
+	}
+}
+
+// WithMappingsFS copies the files found under root in the given file system, e.g. an embed.FS,
+// into the container mappings directory. Nested directories are walked recursively
+// and the paths relative to root are preserved.
+func WithMappingsFS(fsys fs.FS, root string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if err := copyFS(req, fsys, root, path.Join(wireMockRootDir, "mappings")); err != nil {
+			return fmt.Errorf("load mappings fs: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// WithFilesFS copies the files found under root in the given file system, e.g. an embed.FS,
+// into the container __files directory. Nested directories are walked recursively
+// and the paths relative to root are preserved.
+func WithFilesFS(fsys fs.FS, root string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if err := copyFS(req, fsys, root, path.Join(wireMockRootDir, "__files")); err != nil {
+			return fmt.Errorf("load files fs: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// WithStubs serializes the stub rules into mapping files copied into the container,
+// so that the stubs are loaded at startup and available as soon as RunContainer returns.
+// Being file-based, the stubs also survive a reset of the mappings.
+func WithStubs(stubs ...*wiremock.StubRule) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		for i, stub := range stubs {
+			if stub == nil {
+				return fmt.Errorf("load stubs: stub #%d is nil", i)
+			}
+
+			content, err := stub.MarshalJSON()
+			if err != nil {
+				return fmt.Errorf("load stubs: marshal stub %s: %w", stub.UUID(), err)
+			}
+
+			req.Files = append(req.Files, testcontainers.ContainerFile{
+				Reader:            bytes.NewReader(content),
+				ContainerFilePath: path.Join(wireMockRootDir, "mappings", stub.UUID()+".json"),
+				FileMode:          0755,
+			})
+		}
+
+		return nil
+	}
+}
+
+// copyFS adds every file under root in fsys to the request, reading the content eagerly
+// so that the file system does not need to outlive the customizer
+func copyFS(req *testcontainers.GenericContainerRequest, fsys fs.FS, root string, containerDir string) error {
+	return fs.WalkDir(fsys, root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+
+		var rel string
+		switch {
+		case filePath == root:
+			rel = path.Base(filePath)
+		case root == ".":
+			rel = filePath
+		default:
+			rel = strings.TrimPrefix(filePath, root+"/")
+		}
+
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(content),
+			ContainerFilePath: path.Join(containerDir, rel),
+			FileMode:          0755,
+		})
+
+		return nil
+	})
+}
+
+// StubMapping is a stub mapping as returned by the WireMock admin API
+type StubMapping struct {
+	ID                    string         `json:"id,omitempty"`
+	UUID                  string         `json:"uuid,omitempty"`
+	Name                  string         `json:"name,omitempty"`
+	Priority              int            `json:"priority,omitempty"`
+	Persistent            bool           `json:"persistent,omitempty"`
+	ScenarioName          string         `json:"scenarioName,omitempty"`
+	RequiredScenarioState string         `json:"requiredScenarioState,omitempty"`
+	NewScenarioState      string         `json:"newScenarioState,omitempty"`
+	Request               StubRequest    `json:"request"`
+	Response              StubResponse   `json:"response"`
+	Metadata              map[string]any `json:"metadata,omitempty"`
+
+	// raw keeps the mapping as returned by WireMock, including the fields not modelled here
+	raw json.RawMessage
+}
+
+// StubRequest is the request pattern of a stub mapping
+type StubRequest struct {
+	Method          string                    `json:"method,omitempty"`
+	URL             string                    `json:"url,omitempty"`
+	URLPath         string                    `json:"urlPath,omitempty"`
+	URLPattern      string                    `json:"urlPattern,omitempty"`
+	URLPathPattern  string                    `json:"urlPathPattern,omitempty"`
+	URLPathTemplate string                    `json:"urlPathTemplate,omitempty"`
+	Headers         map[string]map[string]any `json:"headers,omitempty"`
+	QueryParameters map[string]map[string]any `json:"queryParameters,omitempty"`
+	BodyPatterns    []map[string]any          `json:"bodyPatterns,omitempty"`
+}
+
+// StubResponse is the response definition of a stub mapping
+type StubResponse struct {
+	Status       int             `json:"status,omitempty"`
+	Headers      map[string]any  `json:"headers,omitempty"`
+	Body         string          `json:"body,omitempty"`
+	JSONBody     json.RawMessage `json:"jsonBody,omitempty"`
+	Base64Body   string          `json:"base64Body,omitempty"`
+	BodyFileName string          `json:"bodyFileName,omitempty"`
+}
+
+// UnmarshalJSON decodes the stub mapping and keeps its raw JSON representation
+func (m *StubMapping) UnmarshalJSON(data []byte) error {
+	type stubMapping StubMapping
+	var decoded stubMapping
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*m = StubMapping(decoded)
+	m.raw = slices.Clone(data)
+
+	return nil
+}
+
+// mappingJSON returns the mapping as returned by WireMock if available, so that no field is lost
+func (m StubMapping) mappingJSON() ([]byte, error) {
+	if m.raw != nil {
+		return m.raw, nil
+	}
+
+	return json.Marshal(m)
+}
@@ -0,0 +1,229 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestWithMappingsDir(t *testing.T) {
+	req, _, err := newContainerRequest(WithMappingsDir(filepath.Join("testdata", "root")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+	for _, f := range req.Files {
+		files[f.ContainerFilePath] = f.HostFilePath
+	}
+
+	expected := map[string]string{
+		"/home/wiremock/mappings/hello.json":          filepath.Join("testdata", "root", "mappings", "hello.json"),
+		"/home/wiremock/mappings/greetings/hola.json": filepath.Join("testdata", "root", "mappings", "greetings", "hola.json"),
+		"/home/wiremock/__files/greetings/hola.txt":   filepath.Join("testdata", "root", "__files", "greetings", "hola.txt"),
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files but got %v", len(expected), files)
+	}
+	for containerPath, hostPath := range expected {
+		if files[containerPath] != hostPath {
+			t.Fatalf("expected %s to be copied to %s but got %v", hostPath, containerPath, files)
+		}
+	}
+}
+
+func TestWithMappingsDirReportsMissingDirectory(t *testing.T) {
+	dir := filepath.Join("testdata", "does-not-exist")
+	_, _, err := newContainerRequest(WithMappingsDir(dir))
+	if err == nil {
+		t.Fatal("expected an error for a directory without mappings")
+	}
+	if !strings.Contains(err.Error(), dir) {
+		t.Fatalf("expected the error to name %s but got %s", dir, err)
+	}
+}
+
+func TestWireMockWithMappingsDir(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingsDir(filepath.Join("testdata", "root")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, out, err := SendHttpGet(container, "/hello", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if out != "Hello, world!" {
+		t.Fatalf("expected 'Hello, world!' but got %s", out)
+	}
+
+	statusCode, out, err = SendHttpGet(container, "/hola", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if out != "Hola, mundo!" {
+		t.Fatalf("expected 'Hola, mundo!' but got %s", out)
+	}
+}
+
+func TestWithMappingsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fixtures/mappings/hello.json":         {Data: []byte(`{"request":{"method":"GET","url":"/hello"},"response":{"status":200}}`)},
+		"fixtures/mappings/nested/hola.json":   {Data: []byte(`{"request":{"method":"GET","url":"/hola"},"response":{"status":200}}`)},
+		"fixtures/__files/nested/greeting.txt": {Data: []byte("Hola, mundo!")},
+	}
+
+	req, _, err := newContainerRequest(
+		WithMappingsFS(fsys, "fixtures/mappings"),
+		WithFilesFS(fsys, "fixtures/__files"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+	for _, f := range req.Files {
+		content, err := io.ReadAll(f.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.ContainerFilePath] = string(content)
+	}
+
+	expected := map[string]string{
+		"/home/wiremock/mappings/hello.json":         string(fsys["fixtures/mappings/hello.json"].Data),
+		"/home/wiremock/mappings/nested/hola.json":   string(fsys["fixtures/mappings/nested/hola.json"].Data),
+		"/home/wiremock/__files/nested/greeting.txt": "Hola, mundo!",
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files but got %v", len(expected), files)
+	}
+	for containerPath, content := range expected {
+		if files[containerPath] != content {
+			t.Fatalf("expected %s to contain %q but got %q", containerPath, content, files[containerPath])
+		}
+	}
+}
+
+func TestWithMappingsFSReportsMissingRoot(t *testing.T) {
+	_, _, err := newContainerRequest(WithMappingsFS(fstest.MapFS{}, "missing"))
+	if err == nil {
+		t.Fatal("expected an error for a missing root")
+	}
+	if !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected the error to name the missing root but got %s", err)
+	}
+}
+
+func TestWireMockWithMappingsFS(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingsFS(os.DirFS(filepath.Join("testdata", "root")), "mappings"),
+		WithFilesFS(os.DirFS(filepath.Join("testdata", "root")), "__files"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, out, err := SendHttpGet(container, "/hola", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if out != "Hola, mundo!" {
+		t.Fatalf("expected 'Hola, mundo!' but got %s", out)
+	}
+}
+
+func TestWithStubs(t *testing.T) {
+	stub := wiremock.Get(wiremock.URLEqualTo("/hello")).
+		WillReturnResponse(wiremock.NewResponse().WithBody("Hello, world!").WithStatus(http.StatusOK))
+
+	req, _, err := newContainerRequest(WithStubs(stub))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(req.Files) != 1 {
+		t.Fatalf("expected 1 mapping file but got %d", len(req.Files))
+	}
+	if req.Files[0].ContainerFilePath != "/home/wiremock/mappings/"+stub.UUID()+".json" {
+		t.Fatalf("unexpected mapping file path %s", req.Files[0].ContainerFilePath)
+	}
+
+	content, err := io.ReadAll(req.Files[0].Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mapping struct {
+		ID      string `json:"id"`
+		Request struct {
+			URL string `json:"url"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal(content, &mapping); err != nil {
+		t.Fatal(err)
+	}
+	if mapping.ID != stub.UUID() || mapping.Request.URL != "/hello" {
+		t.Fatalf("unexpected mapping content %s", content)
+	}
+}
+
+func TestWithStubsRejectsNilStub(t *testing.T) {
+	_, _, err := newContainerRequest(WithStubs(nil))
+	if err == nil {
+		t.Fatal("expected an error for a nil stub")
+	}
+}
+
+func TestWireMockWithStubs(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithStubs(
+			wiremock.Get(wiremock.URLEqualTo("/hello")).
+				WillReturnResponse(
+					wiremock.NewResponse().
+						WithJSONBody(map[string]string{"result": "Hello, world!"}).
+						WithHeader("Content-Type", "application/json").
+						WithStatus(http.StatusOK),
+				),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No waiting nor polling, the stub must be there as soon as the container is returned
+	statusCode, out, err := SendHttpGet(container, "/hello", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if out != `{"result":"Hello, world!"}` {
+		t.Fatalf("expected 'Hello, world!' but got %s", out)
+	}
+}
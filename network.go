@@ -0,0 +1,82 @@
+package testcontainers_wiremock
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+// defaultNetworkAlias is the alias used by WithNetwork when none is given
+const defaultNetworkAlias = "wiremock"
+
+// WithNetwork attaches the container to the Docker network under the given aliases, "wiremock" if none is given,
+// so that other containers on the network can reach it at GetInternalURI.
+// It can be passed several times to attach the container to multiple networks.
+func WithNetwork(nw *testcontainers.DockerNetwork, aliases ...string) Option {
+	return func(o *options) error {
+		if nw == nil {
+			return errors.New("network must not be nil")
+		}
+
+		if len(aliases) == 0 {
+			aliases = []string{defaultNetworkAlias}
+		}
+		if slices.Contains(aliases, "") {
+			return errors.New("network alias must not be empty")
+		}
+
+		o.networks = append(o.networks, networkAttachment{network: nw, aliases: aliases})
+
+		return nil
+	}
+}
+
+type networkAttachment struct {
+	network *testcontainers.DockerNetwork
+	aliases []string
+}
+
+// applyNetworks attaches the container to the networks registered by WithNetwork
+func applyNetworks(req *testcontainers.GenericContainerRequest, settings *options) error {
+	for _, attachment := range settings.networks {
+		if err := network.WithNetwork(attachment.aliases, attachment.network).Customize(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// networkAliases returns all the aliases the container is known as on its networks
+func (o *options) networkAliases() []string {
+	var aliases []string
+	for _, attachment := range o.networks {
+		aliases = append(aliases, attachment.aliases...)
+	}
+
+	return aliases
+}
+
+// GetInternalURI returns the base URI other containers on the network set by WithNetwork
+// can reach WireMock at, using the first network alias
+func (c *WireMockContainer) GetInternalURI() (string, error) {
+	if len(c.networkAliases) == 0 {
+		return "", errors.New("the container is not attached to a network, use the WithNetwork option")
+	}
+
+	return "http://" + c.networkAliases[0] + ":" + defaultPort, nil
+}
+
+// GetInternalHTTPSURI returns the HTTPS counterpart of GetInternalURI, see WithHTTPS
+func (c *WireMockContainer) GetInternalHTTPSURI() (string, error) {
+	if c.tls == nil {
+		return "", errors.New("https is not enabled, use the WithHTTPS option")
+	}
+	if len(c.networkAliases) == 0 {
+		return "", errors.New("the container is not attached to a network, use the WithNetwork option")
+	}
+
+	return "https://" + c.networkAliases[0] + ":" + defaultHTTPSPort, nil
+}
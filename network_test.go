@@ -0,0 +1,108 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestWithNetwork(t *testing.T) {
+	nw := &testcontainers.DockerNetwork{Name: "wiremock-test"}
+
+	req, settings, err := newContainerRequest(WithNetwork(nw, "payments-api", "mock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(req.Networks, "wiremock-test") {
+		t.Fatalf("expected the container to be attached to the network but got %v", req.Networks)
+	}
+	if !slices.Equal(req.NetworkAliases["wiremock-test"], []string{"payments-api", "mock"}) {
+		t.Fatalf("unexpected network aliases %v", req.NetworkAliases)
+	}
+
+	container := &WireMockContainer{networkAliases: settings.networkAliases()}
+	uri, err := container.GetInternalURI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri != "http://payments-api:8080" {
+		t.Fatalf("expected http://payments-api:8080 but got %s", uri)
+	}
+}
+
+func TestWithNetworkDefaultAlias(t *testing.T) {
+	_, settings, err := newContainerRequest(WithNetwork(&testcontainers.DockerNetwork{Name: "wiremock-test"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(settings.networkAliases(), []string{"wiremock"}) {
+		t.Fatalf("expected the default alias but got %v", settings.networkAliases())
+	}
+}
+
+func TestGetInternalURIWithoutNetwork(t *testing.T) {
+	if _, err := (&WireMockContainer{}).GetInternalURI(); err == nil {
+		t.Fatal("expected an error without WithNetwork")
+	}
+}
+
+func TestWireMockWithNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := nw.Remove(ctx); err != nil {
+			t.Fatalf("failed to remove network: %s", err)
+		}
+	})
+
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithNetwork(nw, "payments-api"),
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := container.GetInternalURI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Call the mock by its DNS name from another container on the network
+	client, err := testcontainers.Run(ctx, "docker.io/curlimages/curl:8.10.1",
+		network.WithNetwork(nil, nw),
+		testcontainers.WithCmd("curl", "-s", uri+"/hello"),
+		testcontainers.WithWaitStrategy(wait.ForExit()),
+	)
+	testcontainers.CleanupContainer(t, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := client.Logs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logs.Close()
+
+	out, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "Hello, world!") {
+		t.Fatalf("expected 'Hello, world!' but got %s", out)
+	}
+}
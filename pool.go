@@ -0,0 +1,291 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var errPoolClosed = errors.New("lease: the pool is closed")
+
+// PoolConfig sets the size of a Pool
+type PoolConfig struct {
+	// Size is the number of containers started upfront by NewPool
+	Size int
+	// MaxSize is the number of containers the pool grows up to when all of them are leased,
+	// it defaults to Size
+	MaxSize int
+}
+
+// PoolStats reports the usage of a Pool
+type PoolStats struct {
+	// Size is the number of running containers, leased or not
+	Size int
+	// Idle is the number of containers waiting to be leased
+	Idle int
+	// Leases is the total number of leases
+	Leases int
+	// TotalWait is the time spent waiting for a container, summed over all the leases
+	TotalWait time.Duration
+	// MaxWait is the longest time spent waiting for a container
+	MaxWait time.Duration
+}
+
+// AverageWait returns the average time spent waiting for a container
+func (s PoolStats) AverageWait() time.Duration {
+	if s.Leases == 0 {
+		return 0
+	}
+
+	return s.TotalWait / time.Duration(s.Leases)
+}
+
+// Pool leases pre-warmed WireMock containers, all started with the same customizers,
+// to tests running in parallel. The containers are reset when returned to the pool.
+//
+//	func TestMain(m *testing.M) {
+//		pool, err := NewPool(context.Background(), PoolConfig{Size: 4, MaxSize: 8}, WithMappingsDir("testdata"))
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		code := m.Run()
+//		if err := pool.Close(context.Background()); err != nil {
+//			log.Print(err)
+//		}
+//		os.Exit(code)
+//	}
+type Pool struct {
+	maxSize int
+	start   func(ctx context.Context) (*WireMockContainer, error)
+
+	idle chan *WireMockContainer
+	// capacity is signalled when a container is dropped, to wake up the leases waiting for a container
+	capacity chan struct{}
+	// done is closed by Close to wake up the leases waiting for a container
+	done chan struct{}
+
+	mu         sync.Mutex
+	containers []*WireMockContainer
+	starting   int
+	closed     bool
+	stats      PoolStats
+}
+
+// NewPool starts cfg.Size containers with the given customizers and returns a pool leasing them
+func NewPool(ctx context.Context, cfg PoolConfig, opts ...testcontainers.ContainerCustomizer) (*Pool, error) {
+	return newPool(ctx, cfg, func(ctx context.Context) (*WireMockContainer, error) {
+		return RunContainer(ctx, opts...)
+	})
+}
+
+func newPool(ctx context.Context, cfg PoolConfig, start func(ctx context.Context) (*WireMockContainer, error)) (*Pool, error) {
+	if cfg.Size < 0 {
+		return nil, fmt.Errorf("pool size must not be negative, got %d", cfg.Size)
+	}
+	maxSize := max(cfg.MaxSize, cfg.Size)
+	if maxSize == 0 {
+		return nil, errors.New("pool max size must be positive")
+	}
+
+	p := &Pool{
+		maxSize:  maxSize,
+		start:    start,
+		idle:     make(chan *WireMockContainer, maxSize),
+		capacity: make(chan struct{}, maxSize),
+		done:     make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, cfg.Size)
+	for i := range cfg.Size {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			container, err := start(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			p.mu.Lock()
+			p.containers = append(p.containers, container)
+			p.mu.Unlock()
+			p.idle <- container
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, errors.Join(fmt.Errorf("start pool: %w", err), p.Close(ctx))
+	}
+
+	return p, nil
+}
+
+// Lease returns an idle container, starting a new one if all of them are leased and the pool
+// has not reached its maximum size, or waiting for one to be released otherwise
+func (p *Pool) Lease(ctx context.Context) (*WireMockContainer, error) {
+	begin := time.Now()
+
+	container, err := p.lease(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wait := time.Since(begin)
+	p.mu.Lock()
+	p.stats.Leases++
+	p.stats.TotalWait += wait
+	p.stats.MaxWait = max(p.stats.MaxWait, wait)
+	p.mu.Unlock()
+
+	return container, nil
+}
+
+func (p *Pool) lease(ctx context.Context) (*WireMockContainer, error) {
+	for {
+		select {
+		case <-p.done:
+			return nil, errPoolClosed
+		default:
+		}
+
+		select {
+		case container := <-p.idle:
+			return container, nil
+		default:
+		}
+
+		p.mu.Lock()
+		grow := len(p.containers)+p.starting < p.maxSize
+		if grow {
+			p.starting++
+		}
+		p.mu.Unlock()
+
+		if grow {
+			return p.grow(ctx)
+		}
+
+		// Wait for a container to be released, or for room to start a new one
+		select {
+		case container := <-p.idle:
+			return container, nil
+		case <-p.capacity:
+		case <-p.done:
+			return nil, errPoolClosed
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lease: %w", ctx.Err())
+		}
+	}
+}
+
+func (p *Pool) grow(ctx context.Context) (*WireMockContainer, error) {
+	container, err := p.start(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.starting--
+	if err != nil {
+		p.signalCapacity()
+		return nil, fmt.Errorf("lease: grow the pool: %w", err)
+	}
+	if p.closed {
+		return nil, errors.Join(errPoolClosed, container.Terminate(ctx))
+	}
+	p.containers = append(p.containers, container)
+
+	return container, nil
+}
+
+// signalCapacity wakes up a lease waiting for a container, so that it starts a new one
+func (p *Pool) signalCapacity() {
+	select {
+	case p.capacity <- struct{}{}:
+	default:
+	}
+}
+
+// Release resets the container and returns it to the pool.
+// A container which cannot be reset is terminated and replaced on demand.
+func (p *Pool) Release(ctx context.Context, container *WireMockContainer) error {
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		return nil
+	}
+
+	if err := container.Reset(ctx); err != nil {
+		p.mu.Lock()
+		p.containers = slices.DeleteFunc(p.containers, func(c *WireMockContainer) bool { return c == container })
+		p.signalCapacity()
+		p.mu.Unlock()
+
+		return errors.Join(fmt.Errorf("release: %w", err), container.Terminate(ctx))
+	}
+
+	p.idle <- container
+
+	return nil
+}
+
+// Acquire leases a container for the duration of the test, it is released when the test completes
+func (p *Pool) Acquire(t testing.TB) *WireMockContainer {
+	t.Helper()
+
+	container, err := p.Lease(context.Background())
+	if err != nil {
+		t.Fatalf("failed to lease a container: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := p.Release(context.Background(), container); err != nil {
+			t.Errorf("failed to release the container: %s", err)
+		}
+	})
+
+	return container
+}
+
+// Stats returns the usage statistics of the pool
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Size = len(p.containers)
+	stats.Idle = len(p.idle)
+
+	return stats
+}
+
+// Close terminates all the containers of the pool, including the leased ones
+func (p *Pool) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	containers := p.containers
+	p.containers = nil
+	p.mu.Unlock()
+
+	var errs []error
+	for _, container := range containers {
+		if err := container.Terminate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
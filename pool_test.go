@@ -0,0 +1,194 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// fakeContainer stands for the Docker container in the pool unit tests
+type fakeContainer struct {
+	testcontainers.Container
+	terminated atomic.Bool
+}
+
+func (c *fakeContainer) Terminate(context.Context, ...testcontainers.TerminateOption) error {
+	c.terminated.Store(true)
+	return nil
+}
+
+func newFakePool(t *testing.T, cfg PoolConfig, resetStatus int) (*Pool, *atomic.Int32) {
+	t.Helper()
+
+	var started atomic.Int32
+	pool, err := newPool(context.Background(), cfg, func(context.Context) (*WireMockContainer, error) {
+		started.Add(1)
+		container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(resetStatus)
+		})
+		container.Container = &fakeContainer{}
+		return container, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pool, &started
+}
+
+func TestPoolLease(t *testing.T) {
+	ctx := context.Background()
+	pool, started := newFakePool(t, PoolConfig{Size: 1, MaxSize: 2}, http.StatusOK)
+
+	first, err := pool.Lease(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := pool.Lease(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Fatal("expected two distinct containers")
+	}
+	if started.Load() != 2 {
+		t.Fatalf("expected the pool to grow to 2 containers but started %d", started.Load())
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Lease(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the lease to time out beyond the max size but got %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		if err := pool.Release(ctx, first); err != nil {
+			t.Error(err)
+		}
+	}()
+	third, err := pool.Lease(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third != first {
+		t.Fatal("expected the released container to be leased again")
+	}
+
+	stats := pool.Stats()
+	if stats.Size != 2 || stats.Idle != 0 || stats.Leases != 3 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.MaxWait < 20*time.Millisecond || stats.AverageWait() > stats.MaxWait {
+		t.Fatalf("unexpected wait times %+v", stats)
+	}
+
+	if err := pool.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, container := range []*WireMockContainer{first, second} {
+		if !container.Container.(*fakeContainer).terminated.Load() {
+			t.Fatal("expected the containers to be terminated on close")
+		}
+	}
+	if _, err := pool.Lease(ctx); !errors.Is(err, errPoolClosed) {
+		t.Fatalf("expected the lease to fail on a closed pool but got %v", err)
+	}
+}
+
+func TestPoolReleaseFailedReset(t *testing.T) {
+	ctx := context.Background()
+	pool, started := newFakePool(t, PoolConfig{Size: 1}, http.StatusInternalServerError)
+
+	container, err := pool.Lease(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Release(ctx, container); err == nil {
+		t.Fatal("expected the release to fail")
+	}
+	if !container.Container.(*fakeContainer).terminated.Load() {
+		t.Fatal("expected the container to be terminated")
+	}
+
+	if _, err := pool.Lease(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if started.Load() != 2 {
+		t.Fatalf("expected the container to be replaced but started %d", started.Load())
+	}
+}
+
+func TestPool(t *testing.T) {
+	pool, err := NewPool(context.Background(), PoolConfig{Size: 2},
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := pool.Close(context.Background()); err != nil {
+			t.Fatalf("failed to close the pool: %s", err)
+		}
+	})
+
+	for _, name := range []string{"first", "second", "third"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			container := pool.Acquire(t)
+
+			statusCode, out, err := SendHttpGet(container, "/hello", nil)
+			if err != nil {
+				t.Fatal(err, "Failed to get a response")
+			}
+			if statusCode != 200 {
+				t.Fatalf("expected HTTP-200 but got %d", statusCode)
+			}
+			if out != "Hello, world!" {
+				t.Fatalf("expected 'Hello, world!' but got %s", out)
+			}
+		})
+	}
+}
+
+func TestPoolReleaseFailedResetWakesWaiters(t *testing.T) {
+	ctx := context.Background()
+	pool, started := newFakePool(t, PoolConfig{Size: 1}, http.StatusInternalServerError)
+
+	container, err := pool.Lease(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leased := make(chan error, 1)
+	go func() {
+		waitCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+		_, err := pool.Lease(waitCtx)
+		leased <- err
+	}()
+
+	// Let the lease block on the full pool before the failed release drops the container
+	time.Sleep(50 * time.Millisecond)
+	if err := pool.Release(ctx, container); err == nil {
+		t.Fatal("expected the release to fail")
+	}
+
+	select {
+	case err := <-leased:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the waiting lease to start a replacement container")
+	}
+	if started.Load() != 2 {
+		t.Fatalf("expected the container to be replaced but started %d", started.Load())
+	}
+}
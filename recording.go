@@ -0,0 +1,155 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// RecordSpec customizes the stubs generated by the WireMock recorder,
+// see https://wiremock.org/docs/record-playback/ for the details.
+// Unlike the WireMock defaults, Persist and RepeatsAsScenarios are disabled unless set.
+type RecordSpec struct {
+	// CaptureHeaders lists the request headers to be matched by the generated stubs
+	CaptureHeaders map[string]CaptureHeader `json:"captureHeaders,omitempty"`
+	// RequestBodyPattern sets how the request bodies are matched by the generated stubs
+	RequestBodyPattern *RequestBodyPattern `json:"requestBodyPattern,omitempty"`
+	// ExtractBodyCriteria sets the size above which response bodies are saved to separate files
+	ExtractBodyCriteria *ExtractBodyCriteria `json:"extractBodyCriteria,omitempty"`
+	// Filters restricts the requests the stubs are generated for
+	Filters *RecordFilters `json:"filters,omitempty"`
+	// Persist saves the generated stubs to the container mappings directory
+	Persist bool `json:"persist"`
+	// RepeatsAsScenarios records the repeated identical requests as a scenario
+	RepeatsAsScenarios bool `json:"repeatsAsScenarios"`
+}
+
+// CaptureHeader configures the matching of a captured request header
+type CaptureHeader struct {
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+}
+
+// RequestBodyPattern configures the matcher generated for the request bodies
+type RequestBodyPattern struct {
+	// Matcher is one of "equalTo", "equalToJson", "equalToXml" or "auto"
+	Matcher             string `json:"matcher"`
+	IgnoreArrayOrder    *bool  `json:"ignoreArrayOrder,omitempty"`
+	IgnoreExtraElements *bool  `json:"ignoreExtraElements,omitempty"`
+	CaseInsensitive     *bool  `json:"caseInsensitive,omitempty"`
+}
+
+// ExtractBodyCriteria sets the thresholds above which response bodies are saved to separate files, e.g. "2 kb"
+type ExtractBodyCriteria struct {
+	TextSizeThreshold   string `json:"textSizeThreshold,omitempty"`
+	BinarySizeThreshold string `json:"binarySizeThreshold,omitempty"`
+}
+
+// RecordFilters restricts the requests the stubs are generated for
+type RecordFilters struct {
+	Method     string   `json:"method,omitempty"`
+	URLPattern string   `json:"urlPattern,omitempty"`
+	IDs        []string `json:"ids,omitempty"`
+	// AllowNonProxied includes the requests which were not proxied, e.g. served by a stub, in a snapshot
+	AllowNonProxied bool `json:"allowNonProxied,omitempty"`
+}
+
+type mappingsResponse struct {
+	Mappings []StubMapping `json:"mappings"`
+}
+
+// StartRecording proxies the requests to the target URL and records the responses,
+// the target must be reachable from within the container
+func (c *WireMockContainer) StartRecording(ctx context.Context, targetURL string, spec RecordSpec) error {
+	body := struct {
+		TargetBaseURL string `json:"targetBaseUrl"`
+		RecordSpec
+	}{
+		TargetBaseURL: targetURL,
+		RecordSpec:    spec,
+	}
+
+	if err := c.adminRequest(ctx, http.MethodPost, "/recordings/start", body, nil); err != nil {
+		return fmt.Errorf("start recording: %w", err)
+	}
+
+	return nil
+}
+
+// StopRecording stops the recording started by StartRecording and returns the recorded stub mappings
+func (c *WireMockContainer) StopRecording(ctx context.Context) ([]StubMapping, error) {
+	var res mappingsResponse
+	if err := c.adminRequest(ctx, http.MethodPost, "/recordings/stop", nil, &res); err != nil {
+		return nil, fmt.Errorf("stop recording: %w", err)
+	}
+
+	return res.Mappings, nil
+}
+
+// Snapshot generates stub mappings from the requests already in the request journal
+func (c *WireMockContainer) Snapshot(ctx context.Context, spec RecordSpec) ([]StubMapping, error) {
+	var res mappingsResponse
+	if err := c.adminRequest(ctx, http.MethodPost, "/recordings/snapshot", spec, &res); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	return res.Mappings, nil
+}
+
+// SaveMappings writes the stub mappings to the "mappings" subdirectory of dir, as returned by WireMock,
+// and copies the body files they refer to from the container to the "__files" subdirectory.
+// The resulting directory can be loaded with WithMappingsDir.
+func (c *WireMockContainer) SaveMappings(ctx context.Context, dir string, mappings []StubMapping) error {
+	for i, mapping := range mappings {
+		content, err := mapping.mappingJSON()
+		if err != nil {
+			return fmt.Errorf("save mappings: %w", err)
+		}
+
+		name := mapping.ID
+		if name == "" {
+			name = mapping.UUID
+		}
+		if name == "" {
+			name = fmt.Sprintf("mapping-%d", i)
+		}
+
+		if err := writeFile(filepath.Join(dir, "mappings", name+".json"), content); err != nil {
+			return fmt.Errorf("save mappings: %w", err)
+		}
+
+		if mapping.Response.BodyFileName != "" {
+			if err := c.saveBodyFile(ctx, dir, mapping.Response.BodyFileName); err != nil {
+				return fmt.Errorf("save mappings: body file %s: %w", mapping.Response.BodyFileName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *WireMockContainer) saveBodyFile(ctx context.Context, dir string, name string) error {
+	reader, err := c.CopyFileFromContainer(ctx, path.Join(wireMockRootDir, "__files", name))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(dir, "__files", filepath.FromSlash(name)), content)
+}
+
+func writeFile(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, content, 0644)
+}
@@ -0,0 +1,174 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+const recordedMappings = `{"mappings":[{
+	"id":"6e1b4ea3-8c7a-4b4e-9cd4-7f0a2d3a1f2b",
+	"name":"hello",
+	"request":{"url":"/hello","method":"GET"},
+	"response":{"status":200,"body":"Hello, world!","headers":{"Content-Type":"text/plain"}},
+	"uuid":"6e1b4ea3-8c7a-4b4e-9cd4-7f0a2d3a1f2b",
+	"persistent":true
+}]}`
+
+// newFakeAdminContainer returns a container whose admin API is served by the handler
+func newFakeAdminContainer(t *testing.T, handler http.HandlerFunc) *WireMockContainer {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &WireMockContainer{uri: server.URL, Client: wiremock.NewClient(server.URL)}
+}
+
+func TestRecording(t *testing.T) {
+	var requests []string
+	var startBody map[string]any
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/__admin/recordings/start" {
+			if err := json.NewDecoder(r.Body).Decode(&startBody); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+		_, _ = io.WriteString(w, recordedMappings)
+	})
+
+	ctx := context.Background()
+	err := container.StartRecording(ctx, "http://api.example.com", RecordSpec{
+		CaptureHeaders: map[string]CaptureHeader{"Accept": {}},
+		Persist:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if startBody["targetBaseUrl"] != "http://api.example.com" || startBody["persist"] != true {
+		t.Fatalf("unexpected start recording body %v", startBody)
+	}
+	if _, ok := startBody["captureHeaders"].(map[string]any)["Accept"]; !ok {
+		t.Fatalf("expected the captured headers in the start recording body %v", startBody)
+	}
+
+	mappings, err := container.StopRecording(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 || mappings[0].Request.URL != "/hello" || mappings[0].Response.Body != "Hello, world!" {
+		t.Fatalf("unexpected recorded mappings %+v", mappings)
+	}
+
+	if _, err := container.Snapshot(ctx, RecordSpec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"POST /__admin/recordings/start", "POST /__admin/recordings/stop", "POST /__admin/recordings/snapshot"}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected requests %v but got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Fatalf("expected requests %v but got %v", expected, requests)
+		}
+	}
+}
+
+func TestRecordingReportsErrors(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not recording", http.StatusBadRequest)
+	})
+
+	if _, err := container.StopRecording(context.Background()); err == nil {
+		t.Fatal("expected an error for a bad response status")
+	}
+}
+
+func TestSaveMappings(t *testing.T) {
+	var res mappingsResponse
+	if err := json.Unmarshal([]byte(recordedMappings), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := (&WireMockContainer{}).SaveMappings(context.Background(), dir, res.Mappings); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "mappings", "6e1b4ea3-8c7a-4b4e-9cd4-7f0a2d3a1f2b.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved map[string]any
+	if err := json.Unmarshal(content, &saved); err != nil {
+		t.Fatal(err)
+	}
+	// The fields which are not modelled are preserved too
+	if saved["persistent"] != true || saved["name"] != "hello" {
+		t.Fatalf("unexpected saved mapping %s", content)
+	}
+
+	// The saved directory can be loaded back
+	if _, _, err := newContainerRequest(WithMappingsDir(dir)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWireMockSnapshot(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := SendHttpGet(container, "/hello", nil); err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+
+	mappings, err := container.Snapshot(ctx, RecordSpec{
+		Filters: &RecordFilters{AllowNonProxied: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 {
+		t.Fatalf("expected 1 recorded mapping but got %d", len(mappings))
+	}
+	if mappings[0].Request.URL != "/hello" || mappings[0].Response.Body != "Hello, world!" {
+		t.Fatalf("unexpected recorded mapping %+v", mappings[0])
+	}
+
+	dir := t.TempDir()
+	if err := container.SaveMappings(ctx, dir, mappings); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := RunContainerAndStopOnCleanup(ctx, t, WithMappingsDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, out, err := SendHttpGet(replay, "/hello", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+	if out != "Hello, world!" {
+		t.Fatalf("expected 'Hello, world!' but got %s", out)
+	}
+}
@@ -0,0 +1,121 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// Scenario is the state of a WireMock scenario
+type Scenario struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	State          string   `json:"state"`
+	PossibleStates []string `json:"possibleStates"`
+}
+
+// Scenarios returns the scenarios of the stubs and their current state
+func (c *WireMockContainer) Scenarios(ctx context.Context) ([]Scenario, error) {
+	var res struct {
+		Scenarios []Scenario `json:"scenarios"`
+	}
+	if err := c.adminRequest(ctx, http.MethodGet, "/scenarios", nil, &res); err != nil {
+		return nil, fmt.Errorf("scenarios: %w", err)
+	}
+
+	return res.Scenarios, nil
+}
+
+// SetScenarioState moves the scenario to the given state, available from WireMock 3
+func (c *WireMockContainer) SetScenarioState(ctx context.Context, name string, state string) error {
+	if err := c.requireFeature(featureScenarioState); err != nil {
+		return err
+	}
+
+	body := map[string]string{"state": state}
+	if err := c.adminRequest(ctx, http.MethodPut, "/scenarios/"+url.PathEscape(name)+"/state", body, nil); err != nil {
+		return fmt.Errorf("set scenario state: %w", err)
+	}
+
+	return nil
+}
+
+// ResetScenario moves the scenario back to its initial state, available from WireMock 3
+func (c *WireMockContainer) ResetScenario(ctx context.Context, name string) error {
+	return c.SetScenarioState(ctx, name, wiremock.ScenarioStateStarted)
+}
+
+// ResetAllScenarios moves all the scenarios back to their initial state
+func (c *WireMockContainer) ResetAllScenarios(ctx context.Context) error {
+	if err := c.adminRequest(ctx, http.MethodPost, "/scenarios/reset", nil, nil); err != nil {
+		return fmt.Errorf("reset all scenarios: %w", err)
+	}
+
+	return nil
+}
+
+// ScenarioSequence builds the stubs of a scenario answering the same request with a sequence of responses,
+// e.g. "first 503, then 503, then 200". Once the sequence is exhausted, the last response is repeated.
+//
+//	stubs, err := NewScenarioSequence("flaky", func() *wiremock.StubRule {
+//		return wiremock.Get(wiremock.URLEqualTo("/flaky"))
+//	}).
+//		Then(wiremock.NewResponse().WithStatus(http.StatusServiceUnavailable)).
+//		Then(wiremock.NewResponse().WithStatus(http.StatusServiceUnavailable)).
+//		Then(wiremock.NewResponse().WithStatus(http.StatusOK)).
+//		Stubs()
+type ScenarioSequence struct {
+	name      string
+	stub      func() *wiremock.StubRule
+	responses []wiremock.ResponseInterface
+}
+
+// NewScenarioSequence starts a sequence for the scenario name, stub returns a new stub matching the request
+// each time it is called
+func NewScenarioSequence(name string, stub func() *wiremock.StubRule) *ScenarioSequence {
+	return &ScenarioSequence{name: name, stub: stub}
+}
+
+// Then appends a response to the sequence
+func (s *ScenarioSequence) Then(response wiremock.ResponseInterface) *ScenarioSequence {
+	s.responses = append(s.responses, response)
+	return s
+}
+
+// Stubs returns one stub per response, each moving the scenario to the state of the next one
+func (s *ScenarioSequence) Stubs() ([]*wiremock.StubRule, error) {
+	if s.name == "" {
+		return nil, errors.New("scenario sequence: the scenario name must not be empty")
+	}
+	if len(s.responses) == 0 {
+		return nil, fmt.Errorf("scenario sequence %s: no response", s.name)
+	}
+
+	stubs := make([]*wiremock.StubRule, 0, len(s.responses))
+	for i, response := range s.responses {
+		stub := s.stub().
+			InScenario(s.name).
+			WhenScenarioStateIs(sequenceState(i)).
+			WillReturnResponse(response)
+		if i < len(s.responses)-1 {
+			stub.WillSetStateTo(sequenceState(i + 1))
+		}
+		stubs = append(stubs, stub)
+	}
+
+	return stubs, nil
+}
+
+// sequenceState names the state of the scenario expecting the response at index i
+func sequenceState(i int) string {
+	if i == 0 {
+		return wiremock.ScenarioStateStarted
+	}
+
+	return "step " + strconv.Itoa(i+1)
+}
@@ -0,0 +1,165 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestScenarios(t *testing.T) {
+	var requests []string
+	var stateBody map[string]string
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch r.Method + " " + r.URL.Path {
+		case "GET /__admin/scenarios":
+			_, _ = w.Write([]byte(`{"scenarios": [{"id": "retry", "name": "retry", "state": "Started", "possibleStates": ["Started", "step 2"]}]}`))
+		case "PUT /__admin/scenarios/my retry/state":
+			if err := json.NewDecoder(r.Body).Decode(&stateBody); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	container.version = Version{Major: 3}
+	ctx := context.Background()
+
+	scenarios, err := container.Scenarios(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scenarios) != 1 || scenarios[0].Name != "retry" || scenarios[0].State != wiremock.ScenarioStateStarted {
+		t.Fatalf("unexpected scenarios %+v", scenarios)
+	}
+
+	if err := container.SetScenarioState(ctx, "my retry", "step 2"); err != nil {
+		t.Fatal(err)
+	}
+	if stateBody["state"] != "step 2" {
+		t.Fatalf("expected the state to be sent but got %v", stateBody)
+	}
+
+	if err := container.ResetScenario(ctx, "my retry"); err != nil {
+		t.Fatal(err)
+	}
+	if stateBody["state"] != wiremock.ScenarioStateStarted {
+		t.Fatalf("expected the scenario to be reset but got %v", stateBody)
+	}
+
+	if err := container.ResetAllScenarios(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if last := requests[len(requests)-1]; last != "POST /__admin/scenarios/reset" {
+		t.Fatalf("expected all the scenarios to be reset but got %s", last)
+	}
+	if requests[1] != "PUT /__admin/scenarios/my%20retry/state" {
+		t.Fatalf("expected the scenario name to be escaped but got %s", requests[1])
+	}
+
+	container.version = Version{Major: 2, Minor: 35}
+	var unsupported *UnsupportedFeatureError
+	if err := container.SetScenarioState(ctx, "my retry", "step 2"); !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError but got %v", err)
+	}
+}
+
+func TestScenarioSequence(t *testing.T) {
+	stubs, err := NewScenarioSequence("retry", func() *wiremock.StubRule {
+		return wiremock.Get(wiremock.URLEqualTo("/flaky"))
+	}).
+		Then(wiremock.NewResponse().WithStatus(http.StatusServiceUnavailable)).
+		Then(wiremock.NewResponse().WithStatus(http.StatusServiceUnavailable)).
+		Then(wiremock.NewResponse().WithStatus(http.StatusOK)).
+		Stubs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		required string
+		next     string
+		status   int
+	}{
+		{required: "Started", next: "step 2", status: 503},
+		{required: "step 2", next: "step 3", status: 503},
+		{required: "step 3", next: "", status: 200},
+	}
+	if len(stubs) != len(expected) {
+		t.Fatalf("expected %d stubs but got %d", len(expected), len(stubs))
+	}
+	for i, stub := range stubs {
+		content, err := stub.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var mapping StubMapping
+		if err := json.Unmarshal(content, &mapping); err != nil {
+			t.Fatal(err)
+		}
+
+		if mapping.ScenarioName != "retry" || mapping.RequiredScenarioState != expected[i].required ||
+			mapping.NewScenarioState != expected[i].next || mapping.Response.Status != expected[i].status {
+			t.Fatalf("unexpected stub %d: %s", i, content)
+		}
+	}
+
+	if _, err := NewScenarioSequence("empty", nil).Stubs(); err == nil {
+		t.Fatal("expected an error for a sequence without response")
+	}
+}
+
+func TestWireMockScenarioSequence(t *testing.T) {
+	stubs, err := NewScenarioSequence("retry", func() *wiremock.StubRule {
+		return wiremock.Get(wiremock.URLEqualTo("/flaky"))
+	}).
+		Then(wiremock.NewResponse().WithStatus(http.StatusServiceUnavailable)).
+		Then(wiremock.NewResponse().WithStatus(http.StatusOK)).
+		Stubs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithImage(defaultV3WireMockImage),
+		WithStubs(stubs...),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectStatus := func(expected int) {
+		t.Helper()
+		statusCode, _, err := SendHttpGet(container, "/flaky", nil)
+		if err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+		if statusCode != expected {
+			t.Fatalf("expected HTTP-%d but got %d", expected, statusCode)
+		}
+	}
+
+	expectStatus(503)
+	expectStatus(200)
+	expectStatus(200)
+
+	if err := container.ResetScenario(ctx, "retry"); err != nil {
+		t.Fatal(err)
+	}
+	expectStatus(503)
+
+	if err := container.SetScenarioState(ctx, "retry", "step 2"); err != nil {
+		t.Fatal(err)
+	}
+	scenarios, err := container.Scenarios(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scenarios) != 1 || scenarios[0].State != "step 2" {
+		t.Fatalf("expected the scenario in state 'step 2' but got %+v", scenarios)
+	}
+	expectStatus(200)
+}
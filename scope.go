@@ -0,0 +1,140 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// scopeMetadataKey is the stub metadata key holding the test scope, e.g.
+// {"testcontainers": {"scope": "...", "test": "TestSomething"}}
+const scopeMetadataKey = "testcontainers"
+
+// TestScope registers stubs on behalf of a single test, see WireMockContainer.ForTest
+type TestScope struct {
+	container *WireMockContainer
+	id        string
+	test      string
+
+	mu      sync.Mutex
+	stubIDs []string
+}
+
+// ForTest returns a handle registering stubs tagged with metadata identifying the test.
+// When the test completes, exactly those stubs are removed, so that tests calling t.Parallel()
+// can share a container without their stubs leaking into each other.
+// The verification and journal queries of the handle only consider the requests matched by its stubs.
+func (c *WireMockContainer) ForTest(t testing.TB) *TestScope {
+	t.Helper()
+
+	scope := &TestScope{
+		container: c,
+		id:        rand.Text(),
+		test:      t.Name(),
+	}
+
+	t.Cleanup(func() {
+		if err := scope.removeStubs(context.Background()); err != nil {
+			t.Errorf("failed to remove the stubs of %s: %s", scope.test, err)
+		}
+	})
+
+	return scope
+}
+
+// StubFor registers the stub, tagged with the test metadata
+func (s *TestScope) StubFor(stub *wiremock.StubRule) error {
+	content, err := stub.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("stub for %s: build stub request error: %w", s.test, err)
+	}
+
+	var mapping map[string]any
+	if err := json.Unmarshal(content, &mapping); err != nil {
+		return fmt.Errorf("stub for %s: build stub request error: %w", s.test, err)
+	}
+	mapping["metadata"] = map[string]any{
+		scopeMetadataKey: map[string]string{"scope": s.id, "test": s.test},
+	}
+
+	if err := s.container.adminRequest(context.Background(), http.MethodPost, "/mappings", mapping, nil); err != nil {
+		return fmt.Errorf("stub for %s: %w", s.test, err)
+	}
+
+	s.mu.Lock()
+	s.stubIDs = append(s.stubIDs, stub.UUID())
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Requests returns the journal entries matched by the stubs of the test
+func (s *TestScope) Requests() ([]ServeEvent, error) {
+	s.mu.Lock()
+	stubIDs := slices.Clone(s.stubIDs)
+	s.mu.Unlock()
+
+	var events []ServeEvent
+	for _, id := range stubIDs {
+		matched, err := s.container.Requests(context.Background(), RequestFilter{StubID: id})
+		if err != nil {
+			return nil, fmt.Errorf("requests of %s: %w", s.test, err)
+		}
+		events = append(events, matched...)
+	}
+
+	return events, nil
+}
+
+// GetCountRequests counts the requests matching the pattern among those matched by the stubs of the test
+func (s *TestScope) GetCountRequests(r *wiremock.Request) (int64, error) {
+	events, err := s.Requests()
+	if err != nil {
+		return 0, err
+	}
+
+	found, err := s.container.findRequests(context.Background(), r)
+	if err != nil {
+		return 0, fmt.Errorf("count requests of %s: %w", s.test, err)
+	}
+
+	// Logged requests carry no id, they are correlated one-to-one with the serve events
+	matching := tallyRequests(found)
+
+	var count int64
+	for _, event := range events {
+		if matching.take(event.Request) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Verify checks the count of requests matching the pattern among those matched by the stubs of the test
+func (s *TestScope) Verify(r *wiremock.Request, expectedCount int64) (bool, error) {
+	actualCount, err := s.GetCountRequests(r)
+	if err != nil {
+		return false, err
+	}
+
+	return actualCount == expectedCount, nil
+}
+
+func (s *TestScope) removeStubs(ctx context.Context) error {
+	body := map[string]any{
+		"matchesJsonPath": map[string]string{
+			"expression": "$." + scopeMetadataKey + ".scope",
+			"equalTo":    s.id,
+		},
+	}
+
+	return s.container.adminRequest(ctx, http.MethodPost, "/mappings/remove-by-metadata", body, nil)
+}
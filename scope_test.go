@@ -0,0 +1,173 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestForTest(t *testing.T) {
+	stub := wiremock.Get(wiremock.URLEqualTo("/hello")).
+		WillReturnResponse(wiremock.NewResponse().WithStatus(http.StatusOK))
+
+	var mu sync.Mutex
+	var registered map[string]any
+	var removal map[string]any
+	var matchingStub string
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/__admin/mappings":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewDecoder(r.Body).Decode(&registered)
+		case "/__admin/mappings/remove-by-metadata":
+			_ = json.NewDecoder(r.Body).Decode(&removal)
+		case "/__admin/requests":
+			matchingStub = r.URL.Query().Get("matchingStub")
+			_, _ = io.WriteString(w, `{"requests":[
+				{"id":"1","request":{"method":"GET","absoluteUrl":"http://localhost/hello","loggedDate":1000}},
+				{"id":"2","request":{"method":"GET","absoluteUrl":"http://localhost/hello?lang=es","loggedDate":1001}}
+			]}`)
+		case "/__admin/requests/find":
+			// One request of this test matches, the other one comes from another test
+			_, _ = io.WriteString(w, `{"requests":[
+				{"method":"GET","absoluteUrl":"http://localhost/hello","loggedDate":1000},
+				{"method":"GET","absoluteUrl":"http://localhost/hello","loggedDate":999}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	var scopeID string
+	t.Run("scoped", func(t *testing.T) {
+		scope := container.ForTest(t)
+		scopeID = scope.id
+
+		if err := scope.StubFor(stub); err != nil {
+			t.Fatal(err)
+		}
+
+		metadata := registered["metadata"].(map[string]any)[scopeMetadataKey].(map[string]any)
+		if metadata["scope"] != scope.id || metadata["test"] != t.Name() {
+			t.Fatalf("expected the stub to be tagged with the test scope but got %v", metadata)
+		}
+
+		events, err := scope.Requests()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 2 || matchingStub != stub.UUID() {
+			t.Fatalf("expected the requests matched by %s but got %v", stub.UUID(), events)
+		}
+
+		ok, err := scope.Verify(wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/hello")), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("expected exactly 1 matching request in the scope")
+		}
+	})
+
+	if removal == nil {
+		t.Fatal("expected the stubs to be removed on cleanup")
+	}
+	matcher := removal["matchesJsonPath"].(map[string]any)
+	if matcher["expression"] != "$.testcontainers.scope" || matcher["equalTo"] != scopeID {
+		t.Fatalf("unexpected removal criteria %v", removal)
+	}
+}
+
+func TestScopeVerifySameMillisecond(t *testing.T) {
+	// The scoped stub matched two requests within the same millisecond, only the traced one matches the pattern
+	traced := `{"method":"GET","absoluteUrl":"http://localhost/hello","loggedDate":1000,"headers":{"X-Trace":"abc"}}`
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/requests":
+			_, _ = io.WriteString(w, `{"requests":[
+				{"id":"2","request":{"method":"GET","absoluteUrl":"http://localhost/hello","loggedDate":1000}},
+				{"id":"1","request":`+traced+`}
+			]}`)
+		case "/__admin/requests/find":
+			_, _ = io.WriteString(w, `{"requests":[`+traced+`]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	scope := &TestScope{container: container, test: t.Name(), stubIDs: []string{"hello"}}
+
+	pattern := wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/hello")).WithHeader("X-Trace", wiremock.EqualTo("abc"))
+	ok, err := scope.Verify(pattern, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected exactly 1 matching request in the scope")
+	}
+}
+
+func TestWireMockForTest(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunDefaultContainerAndStopOnCleanup(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Parallel subtests only complete once their group returns
+	t.Run("group", func(t *testing.T) {
+		for _, greeting := range []string{"hello", "hola", "bonjour"} {
+			t.Run(greeting, func(t *testing.T) {
+				t.Parallel()
+
+				scope := container.ForTest(t)
+				err := scope.StubFor(
+					wiremock.Get(wiremock.URLEqualTo("/greeting")).
+						WithHeader("Accept-Language", wiremock.EqualTo(greeting)).
+						WillReturnResponse(wiremock.NewResponse().WithBody(greeting).WithStatus(http.StatusOK)),
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, container.uri+"/greeting", nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Accept-Language", greeting)
+
+				statusCode, out, err := sendTestRequest(t, req)
+				if err != nil {
+					t.Fatal(err, "Failed to get a response")
+				}
+				if statusCode != 200 || out != greeting {
+					t.Fatalf("expected HTTP-200 and %s but got %d and %s", greeting, statusCode, out)
+				}
+
+				ok, err := scope.Verify(wiremock.NewRequest(http.MethodGet, wiremock.URLEqualTo("/greeting")), 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !ok {
+					t.Fatal("expected only the request of this test to be counted")
+				}
+			})
+		}
+	})
+
+	// All the scoped stubs are gone once the subtests completed
+	statusCode, _, err := SendHttpGet(container, "/greeting", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 404 {
+		t.Fatalf("expected HTTP-404 but got %d", statusCode)
+	}
+}
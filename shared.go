@@ -0,0 +1,84 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// SharedContainer is a WireMock container shared by the tests of a package.
+// It is started on the first Acquire and terminated by Run once all the tests have completed:
+//
+//	var wireMock = NewSharedContainer(WithMappingsDir("testdata"))
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(wireMock.Run(m))
+//	}
+//
+//	func TestSomething(t *testing.T) {
+//		container := wireMock.Acquire(t)
+//		// ...
+//	}
+//
+// As the state is reset after each test, tests acquiring a shared container must not run in parallel.
+type SharedContainer struct {
+	opts []testcontainers.ContainerCustomizer
+
+	once      sync.Once
+	container *WireMockContainer
+	err       error
+}
+
+// NewSharedContainer creates a SharedContainer, the container is started with the given customizers on first use
+func NewSharedContainer(opts ...testcontainers.ContainerCustomizer) *SharedContainer {
+	return &SharedContainer{opts: opts}
+}
+
+// Acquire returns the shared container, starting it if needed.
+// Once the test completes, the mappings, requests and scenarios are reset to the baseline loaded from files.
+func (s *SharedContainer) Acquire(t testing.TB) *WireMockContainer {
+	t.Helper()
+
+	s.once.Do(func() {
+		s.container, s.err = RunContainer(context.Background(), s.opts...)
+	})
+	if s.err != nil {
+		t.Fatalf("failed to start the shared container: %s", s.err)
+	}
+
+	t.Cleanup(func() {
+		if err := s.container.Reset(context.Background()); err != nil {
+			t.Errorf("failed to reset the shared container: %s", err)
+		}
+	})
+
+	return s.container
+}
+
+// Run runs the tests and terminates the container afterwards, it is meant to be called from TestMain.
+// A failure to terminate the container turns a successful exit code into a failure.
+func (s *SharedContainer) Run(m *testing.M) int {
+	code := m.Run()
+
+	if err := s.Terminate(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to terminate the shared container: %s\n", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	return code
+}
+
+// Terminate terminates the container if it was started
+func (s *SharedContainer) Terminate(ctx context.Context) error {
+	if s.container == nil {
+		return nil
+	}
+
+	return s.container.Terminate(ctx)
+}
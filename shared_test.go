@@ -0,0 +1,94 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestReset(t *testing.T) {
+	var requests []string
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	})
+
+	if err := container.Reset(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"POST /__admin/mappings/reset", "DELETE /__admin/requests", "POST /__admin/scenarios/reset",
+		"GET /__admin/settings", "POST /__admin/settings",
+	}
+	if !slices.Equal(requests, expected) {
+		t.Fatalf("expected requests %v but got %v", expected, requests)
+	}
+}
+
+func TestSharedContainer(t *testing.T) {
+	shared := NewSharedContainer(
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	t.Cleanup(func() {
+		if err := shared.Terminate(context.Background()); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	var first *WireMockContainer
+	t.Run("first", func(t *testing.T) {
+		first = shared.Acquire(t)
+
+		err := first.Client.StubFor(
+			wiremock.Get(wiremock.URLEqualTo("/temporary")).
+				WillReturnResponse(wiremock.NewResponse().WithStatus(http.StatusOK)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		statusCode, _, err := SendHttpGet(first, "/temporary", nil)
+		if err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+		if statusCode != 200 {
+			t.Fatalf("expected HTTP-200 but got %d", statusCode)
+		}
+	})
+
+	t.Run("second", func(t *testing.T) {
+		second := shared.Acquire(t)
+		if second != first {
+			t.Fatal("expected the same container to be shared")
+		}
+
+		// The stub registered by the previous test is gone, the file-based one is still there
+		statusCode, _, err := SendHttpGet(second, "/temporary", nil)
+		if err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+		if statusCode != 404 {
+			t.Fatalf("expected HTTP-404 but got %d", statusCode)
+		}
+
+		statusCode, _, err = SendHttpGet(second, "/hello", nil)
+		if err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+		if statusCode != 200 {
+			t.Fatalf("expected HTTP-200 but got %d", statusCode)
+		}
+
+		requests, err := second.Client.GetAllRequests()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(requests.Requests) != 2 {
+			t.Fatalf("expected the journal to only hold this test's 2 requests but got %d", len(requests.Requests))
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	subscribePollInterval = 100 * time.Millisecond
+	subscribeBufferSize   = 64
+	// maxSubscribeErrors is the number of consecutive failed polls after which the container is deemed gone
+	maxSubscribeErrors = 3
+)
+
+// Subscribe tails the request journal and sends the serve events selected by the filter as they are received.
+// Only the requests received after Subscribe returns are sent, unless the filter has a Since time.
+// After, Limit and Last, which page through the journal, are ignored.
+// Journal resets do not cause events to be sent twice or missed, as they are tracked by id and date.
+// The channel is closed when ctx is done or the container stops answering, e.g. once terminated.
+func (c *WireMockContainer) Subscribe(ctx context.Context, filter RequestFilter) <-chan ServeEvent {
+	events := make(chan ServeEvent, subscribeBufferSize)
+
+	cursor := journalCursor{last: filter.Since, seen: make(map[string]time.Time)}
+	// Without Since, the events already in the journal are marked as seen before returning,
+	// so that the requests sent right after the subscription are not mistaken for them
+	if filter.Since.IsZero() && !c.pollJournal(ctx, &cursor, filter.StubID, func(ServeEvent) bool { return true }) {
+		close(events)
+		return events
+	}
+
+	go func() {
+		defer close(events)
+
+		for {
+			send := func(event ServeEvent) bool {
+				if !filter.matches(event) {
+					return true
+				}
+				select {
+				case events <- event:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			if !c.pollJournal(ctx, &cursor, filter.StubID, send) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(subscribePollInterval):
+			}
+		}
+	}()
+
+	return events
+}
+
+// pollJournal passes the events not seen yet to send, retrying failed polls.
+// It returns false when ctx is done, send returns false, or the container does not answer anymore.
+func (c *WireMockContainer) pollJournal(ctx context.Context, cursor *journalCursor, stubID string, send func(ServeEvent) bool) bool {
+	for failures := 0; ; {
+		batch, err := c.Requests(ctx, RequestFilter{Since: cursor.since(), StubID: stubID})
+		switch {
+		case ctx.Err() != nil:
+			return false
+		case err != nil:
+			failures++
+			if failures == maxSubscribeErrors {
+				return false
+			}
+		default:
+			for _, event := range batch {
+				if cursor.add(event) && !send(event) {
+					return false
+				}
+			}
+			cursor.prune()
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(subscribePollInterval):
+		}
+	}
+}
+
+// journalCursor tracks the serve events already seen while tailing the journal
+type journalCursor struct {
+	last time.Time
+	seen map[string]time.Time
+}
+
+// since returns the date to poll from, overlapping the last millisecond as the journal is filtered
+// on events strictly after it and several events may be logged in the same millisecond
+func (c *journalCursor) since() time.Time {
+	if c.last.IsZero() {
+		return c.last
+	}
+
+	return c.last.Add(-time.Millisecond)
+}
+
+// add records the event, and returns false when it was already seen
+func (c *journalCursor) add(event ServeEvent) bool {
+	if _, ok := c.seen[event.ID]; ok {
+		return false
+	}
+
+	c.seen[event.ID] = event.Request.LoggedDate
+	if event.Request.LoggedDate.After(c.last) {
+		c.last = event.Request.LoggedDate
+	}
+
+	return true
+}
+
+// prune forgets the events which cannot be returned by the next poll anymore
+func (c *journalCursor) prune() {
+	since := c.since()
+	for id, date := range c.seen {
+		if date.Before(since) {
+			delete(c.seen, id)
+		}
+	}
+}
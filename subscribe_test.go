@@ -0,0 +1,139 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	// Each poll returns the next journal, the subscription ends once they are exhausted
+	journals := []string{
+		`{"requests": [{"id": "a", "request": {"method": "GET", "url": "/before", "loggedDate": 1000}}]}`,
+		`{"requests": [
+			{"id": "c", "request": {"method": "POST", "url": "/ignored", "loggedDate": 1001}},
+			{"id": "b", "request": {"method": "GET", "url": "/first", "loggedDate": 1000}},
+			{"id": "a", "request": {"method": "GET", "url": "/before", "loggedDate": 1000}}
+		]}`,
+		`{"requests": [
+			{"id": "c", "request": {"method": "POST", "url": "/ignored", "loggedDate": 1001}},
+			{"id": "b", "request": {"method": "GET", "url": "/first", "loggedDate": 1000}}
+		]}`,
+		// The journal has been reset
+		`{"requests": [{"id": "d", "request": {"method": "GET", "url": "/after-reset", "loggedDate": 1005}}]}`,
+	}
+	var polls atomic.Int32
+	var sinces []string
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		poll := int(polls.Add(1)) - 1
+		if poll >= len(journals) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		sinces = append(sinces, r.URL.Query().Get("since"))
+		_, _ = w.Write([]byte(journals[poll]))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var urls []string
+	for event := range container.Subscribe(ctx, RequestFilter{Method: http.MethodGet}) {
+		urls = append(urls, event.Request.URL)
+	}
+
+	if ctx.Err() != nil {
+		t.Fatal("expected the channel to be closed once the container stopped answering")
+	}
+	if !slices.Equal(urls, []string{"/first", "/after-reset"}) {
+		t.Fatalf("expected the new GET requests but got %v", urls)
+	}
+	expectedSinces := []string{"", "1970-01-01T00:00:00.999Z", "1970-01-01T00:00:01.000Z", "1970-01-01T00:00:01.000Z"}
+	if !slices.Equal(sinces, expectedSinces) {
+		t.Fatalf("expected polls since %v but got %v", expectedSinces, sinces)
+	}
+}
+
+func TestSubscribeRequestRightAfterSubscription(t *testing.T) {
+	var mu sync.Mutex
+	journal := `{"requests": [{"id": "a", "request": {"method": "GET", "url": "/before", "loggedDate": 1000}}]}`
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write([]byte(journal))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := container.Subscribe(ctx, RequestFilter{})
+
+	// The system under test sends a request before the subscription polls the journal again
+	mu.Lock()
+	journal = `{"requests": [
+		{"id": "b", "request": {"method": "GET", "url": "/after", "loggedDate": 1000}},
+		{"id": "a", "request": {"method": "GET", "url": "/before", "loggedDate": 1000}}
+	]}`
+	mu.Unlock()
+
+	select {
+	case event := <-events:
+		if event.ID != "b" {
+			t.Fatalf("expected the request sent after the subscription but got %s", event.Request.URL)
+		}
+	case <-ctx.Done():
+		t.Fatal("expected the request sent after the subscription to be delivered")
+	}
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"requests": []}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := container.Subscribe(ctx, RequestFilter{})
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected no event")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the channel to be closed when the context is cancelled")
+	}
+}
+
+func TestWireMockSubscribe(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscriptionCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	events := container.Subscribe(subscriptionCtx, RequestFilter{URL: "/hello"})
+
+	for range 2 {
+		if _, _, err := SendHttpGet(container, "/hello", nil); err != nil {
+			t.Fatal(err, "Failed to get a response")
+		}
+
+		select {
+		case event := <-events:
+			if event.Response.Status != 200 {
+				t.Fatalf("expected HTTP-200 but got %d", event.Response.Status)
+			}
+		case <-subscriptionCtx.Done():
+			t.Fatal("expected a serve event for GET /hello")
+		}
+	}
+}
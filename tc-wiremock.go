@@ -2,10 +2,12 @@ package testcontainers_wiremock
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -18,24 +20,88 @@ const defaultPort = "8080"
 
 type WireMockContainer struct {
 	testcontainers.Container
-	version string
-	Client  *wiremock.Client
+	version    Version
+	extensions []WireMockExtension
+	tls        *tlsMaterial
+	uri        string
+	// networkAliases are the aliases the container is known as on the networks set by WithNetwork
+	networkAliases []string
+	Client         *wiremock.Client
 }
 
-type WireMockExtension struct {
-	testcontainers.Container
-	id        string
-	classname string
-	jarPath   string
+// options holds the module settings collected from the Option customizers
+type options struct {
+	extensions []WireMockExtension
+	cliArgs    []cliArg
+	https      bool
+	tlsClients []string
+	tls        *tlsMaterial
+
+	startupTimeout   time.Duration
+	waitStrategies   []wait.Strategy
+	expectedMappings int
+
+	networks []networkAttachment
+}
+
+// Option is a WireMock-specific customizer. Unlike testcontainers.CustomizeRequestOption,
+// it updates the module settings which RunContainer translates into the container request
+// once all the customizers have been applied.
+type Option func(*options) error
+
+// Customize is a no-op which makes Option a testcontainers.ContainerCustomizer,
+// the settings are applied by RunContainer itself.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	return nil
 }
 
 // RunContainer creates an instance of the WireMockContainer type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*WireMockContainer, error) {
+	genericContainerReq, settings, err := newContainerRequest(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast, before starting the container, when the image tag already tells the version
+	err = checkMappingFeatures(genericContainerReq.Files, versionFromImage(genericContainerReq.Image), settings.extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	uri, err := GetURI(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+
+	wireMockContainer := &WireMockContainer{
+		Container:      container,
+		extensions:     settings.extensions,
+		tls:            settings.tls,
+		uri:            uri,
+		networkAliases: settings.networkAliases(),
+		Client:         wiremock.NewClient(uri),
+	}
+
+	wireMockContainer.version = wireMockContainer.detectVersion(ctx, genericContainerReq.Image)
+	err = checkMappingFeatures(genericContainerReq.Files, wireMockContainer.version, settings.extensions)
+	if err != nil {
+		return nil, errors.Join(err, container.Terminate(ctx))
+	}
+
+	return wireMockContainer, nil
+}
+
+// newContainerRequest applies the customizers to the default WireMock container request
+// and returns the request together with the collected module settings
+func newContainerRequest(opts ...testcontainers.ContainerCustomizer) (testcontainers.GenericContainerRequest, options, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        defaultWireMockImage + ":" + defaultWireMockVersion,
 		ExposedPorts: []string{defaultPort + "/tcp"},
-		Cmd:          []string{""},
-		WaitingFor:   wait.ForHTTP("/__admin").WithPort(defaultPort),
 	}
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
@@ -43,29 +109,52 @@ func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomize
 		Started:          true,
 	}
 
+	var settings options
 	for _, opt := range opts {
-		opt.Customize(&genericContainerReq)
+		if apply, ok := opt.(Option); ok {
+			if err := apply(&settings); err != nil {
+				return genericContainerReq, settings, err
+			}
+		}
+
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return genericContainerReq, settings, err
+		}
 	}
 
-	req.Cmd = append(req.Cmd, "--disable-banner")
+	for _, extension := range settings.extensions {
+		genericContainerReq.Files = append(genericContainerReq.Files, testcontainers.ContainerFile{
+			HostFilePath:      extension.jarPath,
+			ContainerFilePath: extension.containerFilePath(),
+			FileMode:          0644,
+		})
 
-	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
-	if err != nil {
-		return nil, err
+		settings.cliArgs = setCLIArg(settings.cliArgs, "extensions", extension.classname)
 	}
 
-	uri, err := GetURI(ctx, container)
+	if err := applyNetworks(&genericContainerReq, &settings); err != nil {
+		return genericContainerReq, settings, err
+	}
+
+	if settings.https {
+		if err := applyHTTPS(&genericContainerReq, &settings); err != nil {
+			return genericContainerReq, settings, err
+		}
+	}
+
+	applyWaitStrategy(&genericContainerReq, &settings)
+
+	cmd, err := buildCommand(genericContainerReq.Cmd, settings.cliArgs)
 	if err != nil {
-		return nil, err
+		return genericContainerReq, settings, err
 	}
+	genericContainerReq.Cmd = cmd
 
-	return &WireMockContainer{
-		Container: container,
-		Client:    wiremock.NewClient(uri),
-	}, nil
+	return genericContainerReq, settings, nil
 }
 
-// Creates an instance of the WireMockContainer type that is automatically terminated upon test completion
+// Creates an instance of the WireMockContainer type that is automatically terminated upon test completion.
+// When the test has failed, the WireMock diagnostics are written to the test log beforehand.
 func RunContainerAndStopOnCleanup(ctx context.Context, t testing.TB, opts ...testcontainers.ContainerCustomizer) (*WireMockContainer, error) {
 	container, err := RunContainer(ctx, opts...)
 	if err != nil {
@@ -74,6 +163,10 @@ func RunContainerAndStopOnCleanup(ctx context.Context, t testing.TB, opts ...tes
 	}
 
 	t.Cleanup(func() {
+		if t.Failed() {
+			t.Log(container.Diagnostics(ctx))
+		}
+
 		if err := container.Terminate(ctx); err != nil {
 			t.Fatalf("failed to terminate container: %s", err)
 		}
@@ -0,0 +1,319 @@
+package testcontainers_wiremock
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const defaultHTTPSPort = "8443"
+const tlsDir = "/var/wiremock/tls"
+
+// tlsServerName is the name the generated server certificate is verified against,
+// regardless of the Docker host address the container is reached through
+const tlsServerName = "localhost"
+
+// keystorePassword protects the generated keystores, which only hold throwaway test material
+const keystorePassword = "wiremock"
+
+// defaultClientName is the client certificate generated by WithMutualTLS when no name is given
+const defaultClientName = "client"
+
+// tlsMaterial holds the certificates generated for the HTTPS endpoint
+type tlsMaterial struct {
+	ca      *certificateAuthority
+	clients map[string]tls.Certificate
+}
+
+// WithHTTPS enables the WireMock HTTPS endpoint on port 8443, using a server certificate
+// signed by a certificate authority generated for the container.
+// Use TLSConfig or HTTPSClient to talk to the endpoint with the generated authority trusted.
+func WithHTTPS() Option {
+	return func(o *options) error {
+		o.https = true
+
+		return nil
+	}
+}
+
+// WithMutualTLS enables the HTTPS endpoint like WithHTTPS and requires the clients to present
+// a certificate signed by the generated certificate authority.
+// A client certificate is generated for each of the given names, "client" if none is given,
+// and can be retrieved with ClientCertificate.
+func WithMutualTLS(clients ...string) Option {
+	return func(o *options) error {
+		if len(clients) == 0 {
+			clients = []string{defaultClientName}
+		}
+
+		for _, client := range clients {
+			if client == "" {
+				return errors.New("client certificate name must not be empty")
+			}
+			if slices.Contains(o.tlsClients, client) {
+				return fmt.Errorf("client certificate %q is already registered", client)
+			}
+			o.tlsClients = append(o.tlsClients, client)
+		}
+
+		o.https = true
+
+		return nil
+	}
+}
+
+// applyHTTPS generates the TLS material and configures the container request to serve HTTPS
+func applyHTTPS(req *testcontainers.GenericContainerRequest, settings *options) error {
+	ca, err := newCertificateAuthority("WireMock Testcontainers CA")
+	if err != nil {
+		return fmt.Errorf("generate https certificates: %w", err)
+	}
+
+	server, err := ca.issue(tlsServerName, x509.ExtKeyUsageServerAuth,
+		append([]string{tlsServerName}, settings.networkAliases()...),
+		[]net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	)
+	if err != nil {
+		return fmt.Errorf("generate https certificates: %w", err)
+	}
+
+	// The legacy encryption is the one understood by every Java runtime WireMock images may ship
+	keystore, err := pkcs12.LegacyDES.Encode(server.PrivateKey, server.Leaf, []*x509.Certificate{ca.cert}, keystorePassword)
+	if err != nil {
+		return fmt.Errorf("encode https keystore: %w", err)
+	}
+
+	keystorePath := path.Join(tlsDir, "keystore.p12")
+	req.Files = append(req.Files, testcontainers.ContainerFile{
+		Reader:            bytes.NewReader(keystore),
+		ContainerFilePath: keystorePath,
+		FileMode:          0644,
+	})
+	req.ExposedPorts = append(req.ExposedPorts, defaultHTTPSPort+"/tcp")
+
+	settings.cliArgs = setCLIArg(settings.cliArgs, "https-port", defaultHTTPSPort)
+	settings.cliArgs = setCLIArg(settings.cliArgs, "https-keystore", keystorePath)
+	settings.cliArgs = setCLIArg(settings.cliArgs, "keystore-type", "PKCS12")
+	settings.cliArgs = setCLIArg(settings.cliArgs, "keystore-password", keystorePassword)
+	settings.cliArgs = setCLIArg(settings.cliArgs, "key-manager-password", keystorePassword)
+
+	material := &tlsMaterial{ca: ca}
+
+	if len(settings.tlsClients) > 0 {
+		material.clients = make(map[string]tls.Certificate, len(settings.tlsClients))
+		for _, client := range settings.tlsClients {
+			cert, err := ca.issue(client, x509.ExtKeyUsageClientAuth, nil, nil)
+			if err != nil {
+				return fmt.Errorf("generate client certificate %q: %w", client, err)
+			}
+			material.clients[client] = cert
+		}
+
+		truststore, err := pkcs12.LegacyDES.EncodeTrustStore([]*x509.Certificate{ca.cert}, keystorePassword)
+		if err != nil {
+			return fmt.Errorf("encode https truststore: %w", err)
+		}
+
+		truststorePath := path.Join(tlsDir, "truststore.p12")
+		req.Files = append(req.Files, testcontainers.ContainerFile{
+			Reader:            bytes.NewReader(truststore),
+			ContainerFilePath: truststorePath,
+			FileMode:          0644,
+		})
+
+		settings.cliArgs = setCLIArg(settings.cliArgs, "https-require-client-cert", "")
+		settings.cliArgs = setCLIArg(settings.cliArgs, "https-truststore", truststorePath)
+		settings.cliArgs = setCLIArg(settings.cliArgs, "truststore-type", "PKCS12")
+		settings.cliArgs = setCLIArg(settings.cliArgs, "truststore-password", keystorePassword)
+	}
+
+	settings.tls = material
+
+	return nil
+}
+
+// GetHTTPSURI returns the base URI of the HTTPS endpoint enabled by WithHTTPS
+func (c *WireMockContainer) GetHTTPSURI(ctx context.Context) (string, error) {
+	if c.tls == nil {
+		return "", errors.New("https is not enabled, use the WithHTTPS option")
+	}
+
+	hostIP, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mappedPort, err := c.MappedPort(ctx, defaultHTTPSPort)
+	if err != nil {
+		return "", err
+	}
+
+	return "https://" + hostIP + ":" + mappedPort.Port(), nil
+}
+
+// TLSConfig returns a TLS configuration trusting the certificate authority generated by WithHTTPS.
+// The server certificate is verified against the "localhost" name whatever the Docker host is.
+// It returns nil if HTTPS is not enabled.
+func (c *WireMockContainer) TLSConfig() *tls.Config {
+	if c.tls == nil {
+		return nil
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(c.tls.ca.cert)
+
+	return &tls.Config{
+		RootCAs:    pool,
+		ServerName: tlsServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// HTTPSClient returns an HTTP client trusting the certificate authority generated by WithHTTPS,
+// presenting the given client certificates if any, see ClientCertificate.
+// It returns nil if HTTPS is not enabled.
+func (c *WireMockContainer) HTTPSClient(certificates ...tls.Certificate) *http.Client {
+	tlsConfig := c.TLSConfig()
+	if tlsConfig == nil {
+		return nil
+	}
+	tlsConfig.Certificates = certificates
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}
+}
+
+// CACertificatePEM returns the PEM encoded certificate authority generated by WithHTTPS,
+// to be trusted by code loading its CA bundle from a file or a string.
+// It returns nil if HTTPS is not enabled.
+func (c *WireMockContainer) CACertificatePEM() []byte {
+	if c.tls == nil {
+		return nil
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.tls.ca.cert.Raw})
+}
+
+// ClientCertificate returns the client certificate generated by WithMutualTLS under the given name
+func (c *WireMockContainer) ClientCertificate(name string) (tls.Certificate, error) {
+	if c.tls == nil || c.tls.clients == nil {
+		return tls.Certificate{}, errors.New("mutual TLS is not enabled, use the WithMutualTLS option")
+	}
+
+	cert, ok := c.tls.clients[name]
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("no client certificate named %q", name)
+	}
+
+	return cert, nil
+}
+
+// UntrustedClientCertificate generates a client certificate signed by another certificate authority,
+// which the container rejects when mutual TLS is enabled
+func (c *WireMockContainer) UntrustedClientCertificate() (tls.Certificate, error) {
+	ca, err := newCertificateAuthority("Untrusted CA")
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return ca.issue("untrusted", x509.ExtKeyUsageClientAuth, nil, nil)
+}
+
+type certificateAuthority struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+func newCertificateAuthority(commonName string) (*certificateAuthority, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := certificateTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificateAuthority{cert: cert, key: key}, nil
+}
+
+// issue creates a certificate signed by the authority
+func (ca *certificateAuthority) issue(commonName string, usage x509.ExtKeyUsage, dnsNames []string, ips []net.IP) (tls.Certificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template, err := certificateTemplate(commonName)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+	template.DNSNames = dnsNames
+	template.IPAddresses = ips
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der, ca.cert.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+func certificateTemplate(commonName string) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"WireMock Testcontainers"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(7 * 24 * time.Hour),
+	}, nil
+}
@@ -0,0 +1,245 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestWithHTTPS(t *testing.T) {
+	req, settings, err := newContainerRequest(WithHTTPS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(req.ExposedPorts, "8443/tcp") {
+		t.Fatalf("expected the HTTPS port to be exposed but got %v", req.ExposedPorts)
+	}
+	for _, arg := range []string{"--https-port", "--https-keystore", "--keystore-password", "--keystore-type"} {
+		if !slices.Contains(req.Cmd, arg) {
+			t.Fatalf("expected %s in the command but got %v", arg, req.Cmd)
+		}
+	}
+
+	i := slices.IndexFunc(req.Files, func(f testcontainers.ContainerFile) bool {
+		return f.ContainerFilePath == "/var/wiremock/tls/keystore.p12"
+	})
+	if i < 0 {
+		t.Fatalf("expected the keystore to be copied into the container")
+	}
+	keystore, err := io.ReadAll(req.Files[i].Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Serve with the certificate from the keystore, as WireMock would
+	key, cert, caCerts, err := pkcs12.DecodeChain(keystore, keystorePassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Hello, TLS!"))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{
+		Certificate: [][]byte{cert.Raw, caCerts[0].Raw},
+		PrivateKey:  key,
+	}}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	container := &WireMockContainer{tls: settings.tls}
+	res, err := container.HTTPSClient().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", res.StatusCode)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(container.CACertificatePEM()) {
+		t.Fatal("expected a PEM encoded CA certificate")
+	}
+}
+
+func TestTLSConfigWithoutHTTPS(t *testing.T) {
+	container := &WireMockContainer{}
+	if container.TLSConfig() != nil || container.HTTPSClient() != nil {
+		t.Fatal("expected no TLS configuration without WithHTTPS")
+	}
+	if _, err := container.GetHTTPSURI(context.Background()); err == nil {
+		t.Fatal("expected an error without WithHTTPS")
+	}
+}
+
+func TestWireMockWithHTTPS(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithHTTPS(),
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := container.GetHTTPSURI(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := container.HTTPSClient().Get(uri + "/hello")
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	defer res.Body.Close()
+
+	out, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", res.StatusCode)
+	}
+	if string(out) != "Hello, world!" {
+		t.Fatalf("expected 'Hello, world!' but got %s", out)
+	}
+
+	// The default client does not trust the generated certificate authority
+	if _, err := http.Get(uri + "/hello"); err == nil {
+		t.Fatal("expected the default client to reject the generated certificate")
+	}
+}
+
+func TestWithMutualTLS(t *testing.T) {
+	req, settings, err := newContainerRequest(WithMutualTLS("partner-a", "partner-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, arg := range []string{"--https-port", "--https-require-client-cert", "--https-truststore", "--truststore-password"} {
+		if !slices.Contains(req.Cmd, arg) {
+			t.Fatalf("expected %s in the command but got %v", arg, req.Cmd)
+		}
+	}
+
+	i := slices.IndexFunc(req.Files, func(f testcontainers.ContainerFile) bool {
+		return f.ContainerFilePath == "/var/wiremock/tls/truststore.p12"
+	})
+	if i < 0 {
+		t.Fatalf("expected the truststore to be copied into the container")
+	}
+	truststore, err := io.ReadAll(req.Files[i].Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trusted, err := pkcs12.DecodeTrustStore(truststore, keystorePassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Require client certificates signed by the truststore authorities, as WireMock would
+	clientCAs := x509.NewCertPool()
+	for _, cert := range trusted {
+		clientCAs.AddCert(cert)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverCAs := x509.NewCertPool()
+	serverCAs.AddCert(server.Certificate())
+	get := func(cert tls.Certificate) error {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: serverCAs, Certificates: []tls.Certificate{cert}},
+		}}
+		res, err := client.Get(server.URL)
+		if err != nil {
+			return err
+		}
+		return res.Body.Close()
+	}
+
+	container := &WireMockContainer{tls: settings.tls}
+	for _, name := range []string{"partner-a", "partner-b"} {
+		cert, err := container.ClientCertificate(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := get(cert); err != nil {
+			t.Fatalf("expected the %s certificate to be accepted but got %s", name, err)
+		}
+	}
+
+	untrusted, err := container.UntrustedClientCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(untrusted); err == nil {
+		t.Fatal("expected the untrusted certificate to be rejected")
+	}
+
+	if _, err := container.ClientCertificate("unknown"); err == nil {
+		t.Fatal("expected an error for an unknown client certificate")
+	}
+}
+
+func TestWithMutualTLSRejectsDuplicateClients(t *testing.T) {
+	if _, _, err := newContainerRequest(WithMutualTLS("client", "client")); err == nil {
+		t.Fatal("expected an error for duplicate client names")
+	}
+}
+
+func TestWireMockWithMutualTLS(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMutualTLS(),
+		WithMappingFile("hello", filepath.Join("testdata", "hello-world.json")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := container.GetHTTPSURI(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := container.ClientCertificate("client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := container.HTTPSClient(cert).Get(uri + "/hello")
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", res.StatusCode)
+	}
+
+	// Without a client certificate the handshake fails
+	if _, err := container.HTTPSClient().Get(uri + "/hello"); err == nil {
+		t.Fatal("expected the request without a client certificate to be rejected")
+	}
+
+	untrusted, err := container.UntrustedClientCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := container.HTTPSClient(untrusted).Get(uri + "/hello"); err == nil {
+		t.Fatal("expected the request with an untrusted client certificate to be rejected")
+	}
+}
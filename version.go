@@ -0,0 +1,343 @@
+package testcontainers_wiremock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var versionRegexp = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// Version is a WireMock version, the zero value stands for an unknown version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a WireMock version or a WireMock Docker image tag, e.g. "3.9.1", "2.35.0-1" or "3.9.1-alpine"
+func ParseVersion(version string) (Version, error) {
+	match := versionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return Version{}, fmt.Errorf("invalid WireMock version %q", version)
+	}
+
+	var parts [3]int
+	for i, part := range match[1:] {
+		if part == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid WireMock version %q: %w", version, err)
+		}
+		parts[i] = n
+	}
+
+	return Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}, nil
+}
+
+// String returns the version formatted as major.minor.patch, or "unknown" for the zero value
+func (v Version) String() string {
+	if v.IsZero() {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// IsZero reports whether the version is unknown
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower, equal or greater than other
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	return compareInt(v.Patch, other.Patch)
+}
+
+// AtLeast reports whether v is equal or greater than other
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+// LessThan reports whether v is lower than other
+func (v Version) LessThan(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+func compareInt(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Version returns the WireMock version run by the container,
+// or the zero value if it could be detected neither from the admin API nor from the image tag
+func (c *WireMockContainer) Version() Version {
+	return c.version
+}
+
+// detectVersion queries the admin API for the version, falling back to the image tag
+// for the WireMock releases which do not expose it
+func (c *WireMockContainer) detectVersion(ctx context.Context, image string) Version {
+	var res struct {
+		Version string `json:"version"`
+	}
+	if err := c.adminRequest(ctx, http.MethodGet, "/version", nil, &res); err == nil {
+		if version, err := ParseVersion(res.Version); err == nil {
+			return version
+		}
+	}
+
+	return versionFromImage(image)
+}
+
+// versionFromImage parses the version from the image tag, e.g. "docker.io/wiremock/wiremock:3.9.1"
+func versionFromImage(image string) Version {
+	image, _, _ = strings.Cut(image, "@")
+
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return Version{}
+	}
+
+	version, err := ParseVersion(image[i+1:])
+	if err != nil {
+		return Version{}
+	}
+
+	return version
+}
+
+// feature is a WireMock capability only available from a given version
+type feature struct {
+	name  string
+	since Version
+	// extension is the class providing the feature to earlier versions, if any
+	extension string
+}
+
+var v3 = Version{Major: 3}
+
+var (
+	featureJSONSchema    = feature{name: "JSON schema matching", since: v3}
+	featurePathTemplate  = feature{name: "URL path templates", since: v3}
+	featureHealth        = feature{name: "the health endpoint", since: v3}
+	featureScenarioState = feature{name: "setting a scenario state", since: v3}
+	featureWebhooks      = feature{name: "webhooks", since: v3, extension: "org.wiremock.webhooks.Webhooks"}
+)
+
+// UnsupportedFeatureError is returned when a feature is used with a WireMock version which does not support it
+type UnsupportedFeatureError struct {
+	Feature  string
+	Required Version
+	Actual   Version
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s requires WireMock %s or later but the container runs WireMock %s, use WithImage to select a newer image",
+		e.Feature, e.Required, e.Actual)
+}
+
+// checkFeature returns an *UnsupportedFeatureError if the feature is not available in the given version.
+// Unknown versions, e.g. custom images, are assumed to support every feature.
+func checkFeature(f feature, version Version, extensions []WireMockExtension) error {
+	if version.IsZero() || version.AtLeast(f.since) {
+		return nil
+	}
+
+	if f.extension != "" && slices.ContainsFunc(extensions, func(e WireMockExtension) bool { return e.classname == f.extension }) {
+		return nil
+	}
+
+	return &UnsupportedFeatureError{Feature: f.name, Required: f.since, Actual: version}
+}
+
+// requireFeature returns an *UnsupportedFeatureError if the container does not support the feature
+func (c *WireMockContainer) requireFeature(f feature) error {
+	return checkFeature(f, c.version, c.extensions)
+}
+
+// checkMappingFeatures verifies that the mappings copied into the container only use features
+// supported by the given version
+func checkMappingFeatures(files []testcontainers.ContainerFile, version Version, extensions []WireMockExtension) error {
+	if version.IsZero() || version.AtLeast(v3) {
+		return nil
+	}
+
+	for _, file := range files {
+		if !strings.HasPrefix(file.ContainerFilePath, wireMockRootDir+"/mappings/") {
+			continue
+		}
+
+		content, err := peekFile(file)
+		if err != nil {
+			return fmt.Errorf("read mapping %s: %w", file.ContainerFilePath, err)
+		}
+
+		for _, f := range mappingFeatures(content) {
+			if err := checkFeature(f, version, extensions); err != nil {
+				return fmt.Errorf("mapping %s: %w", file.ContainerFilePath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// peekFile returns the content of the container file without consuming its reader,
+// readers other than *bytes.Reader cannot be peeked and are reported as empty
+func peekFile(file testcontainers.ContainerFile) ([]byte, error) {
+	switch reader := file.Reader.(type) {
+	case nil:
+		return os.ReadFile(file.HostFilePath)
+	case *bytes.Reader:
+		content := make([]byte, reader.Size())
+		_, err := reader.ReadAt(content, 0)
+		return content, err
+	default:
+		return nil, nil
+	}
+}
+
+// mappingFeatures lists the versioned features a mapping file relies on.
+// Only the request keys, the matcher operators and the serve actions are inspected, as the response
+// and the matcher operands may contain any JSON.
+func mappingFeatures(content []byte) []feature {
+	var file map[string]any
+	if err := json.Unmarshal(content, &file); err != nil {
+		// Invalid mappings are reported by WireMock itself
+		return nil
+	}
+	// A mapping file holds either a single stub or several under "mappings"
+	stubs := []any{file}
+	if mappings, ok := file["mappings"].([]any); ok {
+		stubs = mappings
+	}
+
+	var features []feature
+	add := func(f feature) {
+		if !slices.Contains(features, f) {
+			features = append(features, f)
+		}
+	}
+
+	for _, stub := range stubs {
+		s, ok := stub.(map[string]any)
+		if !ok {
+			continue
+		}
+		request, _ := s["request"].(map[string]any)
+		if _, ok := request["urlPathTemplate"]; ok {
+			add(featurePathTemplate)
+		}
+		if requestUsesOperator(request, "matchesJsonSchema") {
+			add(featureJSONSchema)
+		}
+		if s["serveEventListeners"] != nil {
+			add(featureWebhooks)
+		}
+		if actions, _ := s["postServeActions"].([]any); slices.ContainsFunc(actions, isWebhookAction) {
+			add(featureWebhooks)
+		}
+	}
+
+	return features
+}
+
+// requestUsesOperator tells whether a matcher of the request pattern uses the operator,
+// without looking into the operands, e.g. the JSON of equalToJson
+func requestUsesOperator(request map[string]any, operator string) bool {
+	bodyPatterns, _ := request["bodyPatterns"].([]any)
+	if slices.ContainsFunc(bodyPatterns, func(m any) bool { return usesOperator(m, operator) }) {
+		return true
+	}
+	for _, key := range []string{"headers", "queryParameters", "cookies", "pathParameters", "formParameters"} {
+		matchers, _ := request[key].(map[string]any)
+		for _, m := range matchers {
+			if usesOperator(m, operator) {
+				return true
+			}
+		}
+	}
+	parts, _ := request["multipartPatterns"].([]any)
+	for _, part := range parts {
+		if p, ok := part.(map[string]any); ok && requestUsesOperator(p, operator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// usesOperator tells whether the matcher, or one it combines, uses the operator
+func usesOperator(matcher any, operator string) bool {
+	m, ok := matcher.(map[string]any)
+	if !ok {
+		return false
+	}
+	if _, ok := m[operator]; ok {
+		return true
+	}
+	if usesOperator(m["not"], operator) {
+		return true
+	}
+	for _, key := range []string{"and", "or", "hasExactly", "includes"} {
+		combined, _ := m[key].([]any)
+		if slices.ContainsFunc(combined, func(c any) bool { return usesOperator(c, operator) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isWebhookAction(action any) bool {
+	a, ok := action.(map[string]any)
+	return ok && a["name"] == "webhook"
+}
+
+// Health is the status reported by the WireMock health endpoint
+type Health struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Version string `json:"version"`
+	Uptime  int64  `json:"uptimeInSeconds"`
+}
+
+// Health queries the WireMock health endpoint, available from WireMock 3
+func (c *WireMockContainer) Health(ctx context.Context) (*Health, error) {
+	if err := c.requireFeature(featureHealth); err != nil {
+		return nil, err
+	}
+
+	var health Health
+	if err := c.adminRequest(ctx, http.MethodGet, "/health", nil, &health); err != nil {
+		return nil, fmt.Errorf("health: %w", err)
+	}
+
+	return &health, nil
+}
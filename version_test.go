@@ -0,0 +1,197 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected Version
+	}{
+		{version: "3.9.1", expected: Version{3, 9, 1}},
+		{version: "v3.9.1", expected: Version{3, 9, 1}},
+		{version: "2.35.0-1", expected: Version{2, 35, 0}},
+		{version: "3.9.1-alpine", expected: Version{3, 9, 1}},
+		{version: "3.0.0-beta-10", expected: Version{3, 0, 0}},
+		{version: "3", expected: Version{3, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		version, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != tt.expected {
+			t.Fatalf("expected %s to be parsed as %s but got %s", tt.version, tt.expected, version)
+		}
+	}
+
+	if _, err := ParseVersion("latest"); err == nil {
+		t.Fatal("expected an error for a version without numbers")
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	v2 := Version{2, 35, 0}
+	v3 := Version{3, 9, 1}
+
+	if v2.Compare(v3) != -1 || v3.Compare(v2) != 1 || v3.Compare(v3) != 0 {
+		t.Fatal("unexpected comparison result")
+	}
+	if !v3.AtLeast(Version{3, 0, 0}) || v2.AtLeast(Version{3, 0, 0}) {
+		t.Fatal("unexpected AtLeast result")
+	}
+	if !v2.LessThan(v3) || v3.LessThan(v2) {
+		t.Fatal("unexpected LessThan result")
+	}
+	if (Version{}).String() != "unknown" || v3.String() != "3.9.1" {
+		t.Fatal("unexpected String result")
+	}
+}
+
+func TestVersionFromImage(t *testing.T) {
+	tests := map[string]Version{
+		"docker.io/wiremock/wiremock:3.9.1":    {3, 9, 1},
+		"docker.io/wiremock/wiremock:2.35.0-1": {2, 35, 0},
+		"localhost:5000/wiremock/wiremock":     {},
+		"wiremock/wiremock:latest":             {},
+		"wiremock/wiremock@sha256:0123abcd":    {},
+	}
+
+	for image, expected := range tests {
+		if version := versionFromImage(image); version != expected {
+			t.Fatalf("expected %s for %s but got %s", expected, image, version)
+		}
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/__admin/version" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, `{"version":"3.9.2"}`)
+	})
+	if version := container.detectVersion(context.Background(), defaultWireMockImage+":3.9.1"); version != (Version{3, 9, 2}) {
+		t.Fatalf("expected the version reported by the admin API but got %s", version)
+	}
+
+	legacy := newFakeAdminContainer(t, http.NotFound)
+	if version := legacy.detectVersion(context.Background(), defaultWireMockImage+":2.35.0-1"); version != (Version{2, 35, 0}) {
+		t.Fatalf("expected the version from the image tag but got %s", version)
+	}
+}
+
+func TestRunContainerRejectsV3MappingsOnV2(t *testing.T) {
+	_, err := RunContainer(context.Background(),
+		WithMappingFile("v3", filepath.Join("testdata", "200-v3-json-schema.json")),
+	)
+
+	var unsupported *UnsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError but got %v", err)
+	}
+	if unsupported.Feature != featureJSONSchema.name || unsupported.Actual != (Version{2, 35, 0}) {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
+
+func TestRunContainerRejectsV3StubsOnV2(t *testing.T) {
+	_, err := RunContainer(context.Background(),
+		WithStubs(wiremock.Get(wiremock.URLPathTemplate("/contacts/{id}"))),
+	)
+
+	var unsupported *UnsupportedFeatureError
+	if !errors.As(err, &unsupported) || unsupported.Feature != featurePathTemplate.name {
+		t.Fatalf("expected an UnsupportedFeatureError for path templates but got %v", err)
+	}
+}
+
+func TestMappingFeatures(t *testing.T) {
+	webhook := []byte(`{"request":{"url":"/"},"postServeActions":[{"name":"webhook","parameters":{"url":"http://example.com"}}]}`)
+	features := mappingFeatures(webhook)
+	if len(features) != 1 || features[0] != featureWebhooks {
+		t.Fatalf("expected webhooks to be detected but got %v", features)
+	}
+
+	// The v2 webhooks extension provides the feature to earlier versions
+	v2 := Version{2, 35, 0}
+	if err := checkFeature(featureWebhooks, v2, nil); err == nil {
+		t.Fatal("expected webhooks to be unsupported by WireMock 2")
+	}
+	extension := WireMockExtension{id: "webhooks", classname: "org.wiremock.webhooks.Webhooks", jarPath: "webhooks.jar"}
+	if err := checkFeature(featureWebhooks, v2, []WireMockExtension{extension}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mapping := range []string{
+		`{"request":{"url":"/hello"},"response":{"status":200}}`,
+		`{"request":{"url":"/hello"},"response":{"jsonBody":{"urlPathTemplate":"/x/{id}"}}}`,
+		`{"request":{"url":"/hello","bodyPatterns":[{"equalToJson":{"urlPathTemplate":"/x","matchesJsonSchema":"{}"}}]}}`,
+	} {
+		if features := mappingFeatures([]byte(mapping)); len(features) != 0 {
+			t.Fatalf("expected no versioned feature in %s but got %v", mapping, features)
+		}
+	}
+
+	combined := []byte(`{"request":{"url":"/","headers":{"X-Payload":{"not":{"and":[{"matchesJsonSchema":"{}"}]}}}}}`)
+	if features := mappingFeatures(combined); !slices.Equal(features, []feature{featureJSONSchema}) {
+		t.Fatalf("expected the JSON schema of the combined matcher to be detected but got %v", features)
+	}
+
+	several := []byte(`{"mappings":[{"request":{"urlPathTemplate":"/x/{id}"}},{"request":{"bodyPatterns":[{"matchesJsonSchema":"{}"}]}}]}`)
+	if features := mappingFeatures(several); !slices.Equal(features, []feature{featurePathTemplate, featureJSONSchema}) {
+		t.Fatalf("expected the features of every stub of the file but got %v", features)
+	}
+}
+
+func TestHealthRequiresV3(t *testing.T) {
+	container := &WireMockContainer{version: Version{2, 35, 0}}
+
+	var unsupported *UnsupportedFeatureError
+	if _, err := container.Health(context.Background()); !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError but got %v", err)
+	}
+}
+
+func TestWireMockVersion(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunDefaultContainerAndStopOnCleanup(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if container.Version() != (Version{2, 35, 0}) {
+		t.Fatalf("expected WireMock 2.35.0 but got %s", container.Version())
+	}
+}
+
+func TestV3WireMockVersion(t *testing.T) {
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t, WithImage(defaultV3WireMockImage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if container.Version() != (Version{3, 9, 1}) {
+		t.Fatalf("expected WireMock 3.9.1 but got %s", container.Version())
+	}
+
+	health, err := container.Health(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.Status != "healthy" {
+		t.Fatalf("expected a healthy container but got %s", health.Status)
+	}
+}
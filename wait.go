@@ -0,0 +1,116 @@
+package testcontainers_wiremock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// WithStartupTimeout sets how long to wait for the container to be ready,
+// instead of the testcontainers default of one minute
+func WithStartupTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		if timeout <= 0 {
+			return fmt.Errorf("startup timeout must be positive, got %s", timeout)
+		}
+
+		o.startupTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithWaitStrategy replaces the default readiness check, which polls the admin API
+// or the health endpoint for WireMock 3 images
+func WithWaitStrategy(strategies ...wait.Strategy) Option {
+	return func(o *options) error {
+		o.waitStrategies = strategies
+
+		return nil
+	}
+}
+
+// WithWaitForMappings waits, on top of the readiness check, for the admin API to report
+// at least the given number of stub mappings, proving the mapping files were loaded
+func WithWaitForMappings(count int) Option {
+	return func(o *options) error {
+		if count <= 0 {
+			return fmt.Errorf("expected mappings count must be positive, got %d", count)
+		}
+
+		o.expectedMappings = count
+
+		return nil
+	}
+}
+
+// applyWaitStrategy sets the wait strategy of the request from the module settings.
+// A strategy set by testcontainers.WithWaitStrategy is kept unless WithWaitStrategy is used too.
+func applyWaitStrategy(req *testcontainers.GenericContainerRequest, settings *options) {
+	timeout := settings.startupTimeout
+
+	strategies := settings.waitStrategies
+	if len(strategies) == 0 {
+		if req.WaitingFor != nil {
+			strategies = []wait.Strategy{req.WaitingFor}
+		} else {
+			strategies = []wait.Strategy{defaultWaitStrategy(versionFromImage(req.Image), timeout)}
+		}
+	}
+
+	if settings.expectedMappings > 0 {
+		strategies = append(strategies, waitForMappings(settings.expectedMappings, timeout))
+	}
+
+	if len(strategies) == 1 && timeout == 0 {
+		req.WaitingFor = strategies[0]
+		return
+	}
+
+	all := wait.ForAll(strategies...)
+	if timeout > 0 {
+		all = all.WithStartupTimeoutDefault(timeout).WithDeadline(timeout)
+	}
+	req.WaitingFor = all
+}
+
+// defaultWaitStrategy polls the health endpoint of WireMock 3, or the admin API of earlier or unknown versions
+func defaultWaitStrategy(version Version, timeout time.Duration) *wait.HTTPStrategy {
+	endpoint := adminPath
+	if version.AtLeast(featureHealth.since) {
+		endpoint = adminPath + "/health"
+	}
+
+	strategy := wait.ForHTTP(endpoint).WithPort(defaultPort)
+	if timeout > 0 {
+		strategy = strategy.WithStartupTimeout(timeout)
+	}
+
+	return strategy
+}
+
+func waitForMappings(count int, timeout time.Duration) *wait.HTTPStrategy {
+	strategy := wait.ForHTTP(adminPath + "/mappings").
+		WithPort(defaultPort).
+		WithResponseMatcher(func(body io.Reader) bool {
+			var res struct {
+				Meta struct {
+					Total int `json:"total"`
+				} `json:"meta"`
+			}
+			if err := json.NewDecoder(body).Decode(&res); err != nil {
+				return false
+			}
+
+			return res.Meta.Total >= count
+		})
+	if timeout > 0 {
+		strategy = strategy.WithStartupTimeout(timeout)
+	}
+
+	return strategy
+}
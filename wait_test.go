@@ -0,0 +1,122 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDefaultWaitStrategy(t *testing.T) {
+	tests := map[string]string{
+		defaultWireMockImage + ":" + defaultWireMockVersion: "/__admin",
+		defaultV3WireMockImage:                              "/__admin/health",
+		defaultWireMockImage + ":latest":                    "/__admin",
+	}
+
+	for image, path := range tests {
+		req, _, err := newContainerRequest(WithImage(image))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		strategy, ok := req.WaitingFor.(*wait.HTTPStrategy)
+		if !ok {
+			t.Fatalf("expected an HTTP wait strategy but got %T", req.WaitingFor)
+		}
+		if strategy.Path != path {
+			t.Fatalf("expected %s to wait for %s but got %s", image, path, strategy.Path)
+		}
+	}
+}
+
+func TestWithStartupTimeout(t *testing.T) {
+	req, _, err := newContainerRequest(WithStartupTimeout(3 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategy, ok := req.WaitingFor.(*wait.MultiStrategy)
+	if !ok {
+		t.Fatalf("expected a multi wait strategy but got %T", req.WaitingFor)
+	}
+	if *strategy.Timeout() != 3*time.Minute {
+		t.Fatalf("expected a 3m timeout but got %s", *strategy.Timeout())
+	}
+	if *strategy.Strategies[0].(*wait.HTTPStrategy).Timeout() != 3*time.Minute {
+		t.Fatal("expected the timeout to apply to the default strategy too")
+	}
+
+	if _, _, err := newContainerRequest(WithStartupTimeout(0)); err == nil {
+		t.Fatal("expected an error for a zero timeout")
+	}
+}
+
+func TestWithWaitStrategy(t *testing.T) {
+	custom := wait.ForLog("port: 8080")
+
+	req, _, err := newContainerRequest(WithWaitStrategy(custom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.WaitingFor != custom {
+		t.Fatalf("expected the custom wait strategy but got %v", req.WaitingFor)
+	}
+
+	// The strategy set by the generic testcontainers customizer is kept too
+	req, _, err = newContainerRequest(testcontainers.WithWaitStrategy(custom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(req.WaitingFor.(*wait.MultiStrategy).String(), "port: 8080") {
+		t.Fatalf("expected the custom wait strategy but got %v", req.WaitingFor)
+	}
+}
+
+func TestWithWaitForMappings(t *testing.T) {
+	req, _, err := newContainerRequest(WithWaitForMappings(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategy, ok := req.WaitingFor.(*wait.MultiStrategy)
+	if !ok || len(strategy.Strategies) != 2 {
+		t.Fatalf("expected the default and the mappings wait strategies but got %v", req.WaitingFor)
+	}
+
+	mappings := strategy.Strategies[1].(*wait.HTTPStrategy)
+	if mappings.Path != "/__admin/mappings" {
+		t.Fatalf("expected to wait for /__admin/mappings but got %s", mappings.Path)
+	}
+	if mappings.ResponseMatcher(strings.NewReader(`{"mappings":[{}],"meta":{"total":1}}`)) {
+		t.Fatal("expected 1 mapping not to be enough")
+	}
+	if !mappings.ResponseMatcher(strings.NewReader(`{"mappings":[{},{}],"meta":{"total":2}}`)) {
+		t.Fatal("expected 2 mappings to be enough")
+	}
+}
+
+func TestWireMockWithWaitForMappings(t *testing.T) {
+	// Create Container
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithMappingsDir(filepath.Join("testdata", "root")),
+		WithWaitForMappings(2),
+		WithStartupTimeout(2*time.Minute),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, _, err := SendHttpGet(container, "/hola", nil)
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 200 {
+		t.Fatalf("expected HTTP-200 but got %d", statusCode)
+	}
+}
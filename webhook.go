@@ -0,0 +1,229 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// webhookExtensionName is the name of the post-serve action firing webhooks
+const webhookExtensionName = "webhook"
+
+// Webhook is a callback WireMock sends after serving a stub, available from WireMock 3
+// or with the webhooks extension. Unlike wiremock.Webhook, the delay is optional.
+type Webhook struct {
+	name    string
+	method  string
+	url     string
+	headers map[string]string
+	body    string
+	delay   wiremock.DelayInterface
+}
+
+// NewWebhook creates a webhook sending a request to the URL, see HostURL and WebhookReceiver.URL
+// for the servers of the test process
+func NewWebhook(method string, url string) Webhook {
+	return Webhook{name: webhookExtensionName, method: method, url: url}
+}
+
+// WithHeader sets a header of the webhook request
+func (w Webhook) WithHeader(key string, value string) Webhook {
+	// Copied so that webhooks derived from the same one do not share their headers
+	w.headers = maps.Clone(w.headers)
+	if w.headers == nil {
+		w.headers = make(map[string]string)
+	}
+	w.headers[key] = value
+	return w
+}
+
+// WithBody sets the body of the webhook request, which is a response template,
+// e.g. {"id": "{{jsonPath originalRequest.body '$.id'}}"}
+func (w Webhook) WithBody(body string) Webhook {
+	w.body = body
+	return w
+}
+
+// WithDelay delays the webhook request, e.g. with wiremock.NewFixedDelay
+func (w Webhook) WithDelay(delay wiremock.DelayInterface) Webhook {
+	w.delay = delay
+	return w
+}
+
+// WithName implements wiremock.WebhookInterface
+func (w Webhook) WithName(name string) wiremock.WebhookInterface {
+	w.name = name
+	return w
+}
+
+// ParseWebhook implements wiremock.WebhookInterface
+func (w Webhook) ParseWebhook() map[string]any {
+	parameters := map[string]any{
+		"method": w.method,
+		"url":    w.url,
+	}
+	if len(w.headers) > 0 {
+		parameters["headers"] = w.headers
+	}
+	if w.body != "" {
+		parameters["body"] = w.body
+	}
+	if w.delay != nil {
+		parameters["delay"] = w.delay.ParseDelay()
+	}
+
+	return map[string]any{
+		"name":       w.name,
+		"parameters": parameters,
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.ParseWebhook())
+}
+
+// WithWebhooks attaches the webhooks to the stub
+func WithWebhooks(stub *wiremock.StubRule, webhooks ...Webhook) *wiremock.StubRule {
+	for _, webhook := range webhooks {
+		stub.WithPostServeAction(webhookExtensionName, webhook)
+	}
+
+	return stub
+}
+
+// StubWebhooks registers the stub, firing the webhooks each time it is served.
+// It returns an *UnsupportedFeatureError before WireMock 3, unless the webhooks extension is loaded.
+func (c *WireMockContainer) StubWebhooks(ctx context.Context, stub *wiremock.StubRule, webhooks ...Webhook) error {
+	if err := c.requireFeature(featureWebhooks); err != nil {
+		return err
+	}
+
+	if err := c.adminRequest(ctx, http.MethodPost, "/mappings", WithWebhooks(stub, webhooks...), nil); err != nil {
+		return fmt.Errorf("stub webhooks: %w", err)
+	}
+
+	return nil
+}
+
+// ReceivedWebhook is a webhook request received by a WebhookReceiver
+type ReceivedWebhook struct {
+	Method     string
+	URL        string
+	Headers    http.Header
+	Body       Body
+	ReceivedAt time.Time
+}
+
+// WebhookReceiver is an HTTP server of the test process recording the webhooks sent by WireMock.
+// Its port must be exposed to the container with WithHostPortAccess(receiver.Port()).
+type WebhookReceiver struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	received []ReceivedWebhook
+	// changed is closed, then replaced, each time a webhook is received
+	changed chan struct{}
+}
+
+// NewWebhookReceiver starts a webhook receiver, stopped when the test completes
+func NewWebhookReceiver(t testing.TB) *WebhookReceiver {
+	receiver := &WebhookReceiver{changed: make(chan struct{})}
+	receiver.server = httptest.NewServer(http.HandlerFunc(receiver.receive))
+	t.Cleanup(receiver.server.Close)
+
+	return receiver
+}
+
+func (r *WebhookReceiver) receive(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	r.received = append(r.received, ReceivedWebhook{
+		Method:     req.Method,
+		URL:        req.URL.RequestURI(),
+		Headers:    req.Header.Clone(),
+		Body:       body,
+		ReceivedAt: time.Now(),
+	})
+	close(r.changed)
+	r.changed = make(chan struct{})
+	r.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Port returns the port to expose with WithHostPortAccess
+func (r *WebhookReceiver) Port() int {
+	return HostPort(r.server)
+}
+
+// URL returns the URL of the receiver as seen from the container, followed by the path
+func (r *WebhookReceiver) URL(path string) string {
+	return HostURL(r.server) + path
+}
+
+// Received returns the webhooks received so far
+func (r *WebhookReceiver) Received() []ReceivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.received)
+}
+
+// WaitFor waits until at least count webhooks have been received and returns them.
+// When ctx is done first, the error describes the webhooks received instead.
+func (r *WebhookReceiver) WaitFor(ctx context.Context, count int) ([]ReceivedWebhook, error) {
+	for {
+		r.mu.Lock()
+		received := slices.Clone(r.received)
+		changed := r.changed
+		r.mu.Unlock()
+
+		if len(received) >= count {
+			return received, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var message strings.Builder
+			fmt.Fprintf(&message, "expected at least %d webhooks but got %d", count, len(received))
+			for _, webhook := range received {
+				fmt.Fprintf(&message, "\n  %s %s", webhook.Method, webhook.URL)
+			}
+			return received, fmt.Errorf("wait for webhooks: %w: %s", ctx.Err(), message.String())
+		}
+	}
+}
+
+// AssertReceived waits up to timeout for at least count webhooks.
+// On failure, it reports the error with the webhooks received instead.
+func (r *WebhookReceiver) AssertReceived(t testing.TB, count int, timeout time.Duration) []ReceivedWebhook {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	received, err := r.WaitFor(ctx, count)
+	if err != nil {
+		t.Error(err)
+	}
+
+	return received
+}
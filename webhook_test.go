@@ -0,0 +1,151 @@
+package testcontainers_wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestWebhook(t *testing.T) {
+	base := NewWebhook(http.MethodPost, "http://host.testcontainers.internal:8080/callback").
+		WithHeader("Content-Type", "application/json")
+	webhook := base.
+		WithHeader("X-Trace", "abc").
+		WithBody(`{"id": "{{jsonPath originalRequest.body '$.id'}}"}`)
+
+	if len(base.headers) != 1 {
+		t.Fatalf("expected the derived webhook not to share its headers but got %v", base.headers)
+	}
+
+	stub := WithWebhooks(wiremock.Post(wiremock.URLEqualTo("/orders")), webhook, base.WithDelay(wiremock.NewFixedDelay(time.Second)))
+	content, err := stub.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mapping struct {
+		PostServeActions []struct {
+			Name       string         `json:"name"`
+			Parameters map[string]any `json:"parameters"`
+		} `json:"postServeActions"`
+	}
+	if err := json.Unmarshal(content, &mapping); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mapping.PostServeActions) != 2 {
+		t.Fatalf("expected 2 webhooks but got %s", content)
+	}
+	first, second := mapping.PostServeActions[0], mapping.PostServeActions[1]
+	if first.Name != "webhook" || first.Parameters["method"] != "POST" || first.Parameters["delay"] != nil ||
+		first.Parameters["headers"].(map[string]any)["X-Trace"] != "abc" || !strings.Contains(first.Parameters["body"].(string), "jsonPath") {
+		t.Fatalf("unexpected webhook %v", first)
+	}
+	if second.Parameters["delay"].(map[string]any)["milliseconds"] != 1000.0 || second.Parameters["body"] != nil {
+		t.Fatalf("unexpected delayed webhook %v", second)
+	}
+	if features := mappingFeatures(content); len(features) != 1 || features[0] != featureWebhooks {
+		t.Fatalf("expected the stub to require webhooks but got %v", features)
+	}
+}
+
+func TestStubWebhooks(t *testing.T) {
+	var registered map[string]any
+	container := newFakeAdminContainer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&registered); err != nil {
+			t.Error(err)
+		}
+	})
+	stub := func() *wiremock.StubRule { return wiremock.Post(wiremock.URLEqualTo("/orders")) }
+	webhook := NewWebhook(http.MethodPost, "http://host.testcontainers.internal:8080/callback")
+	ctx := context.Background()
+
+	container.version = Version{Major: 2, Minor: 35}
+	var unsupported *UnsupportedFeatureError
+	if err := container.StubWebhooks(ctx, stub(), webhook); !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeatureError but got %v", err)
+	}
+
+	container.version = Version{Major: 3}
+	if err := container.StubWebhooks(ctx, stub(), webhook); err != nil {
+		t.Fatal(err)
+	}
+	if actions, _ := registered["postServeActions"].([]any); len(actions) != 1 {
+		t.Fatalf("expected the stub to be registered with the webhook but got %v", registered)
+	}
+}
+
+func TestWebhookReceiver(t *testing.T) {
+	receiver := NewWebhookReceiver(t)
+	if !strings.HasPrefix(receiver.URL("/callback"), "http://host.testcontainers.internal:") {
+		t.Fatalf("unexpected receiver URL %s", receiver.URL("/callback"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := receiver.WaitFor(ctx, 1); !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "expected at least 1 webhooks but got 0") {
+		t.Fatalf("expected the wait to time out but got %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		res, err := http.Post(receiver.server.URL+"/callback?order=42", "application/json", strings.NewReader(`{"id": "42"}`))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = res.Body.Close()
+	}()
+
+	received := receiver.AssertReceived(t, 1, 5*time.Second)
+	if len(received) != 1 || received[0].Method != http.MethodPost || received[0].URL != "/callback?order=42" ||
+		received[0].Headers.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected webhooks %+v", received)
+	}
+	var order struct{ ID string }
+	if err := received[0].Body.DecodeJSON(&order); err != nil || order.ID != "42" {
+		t.Fatalf("expected the JSON body to be decoded but got %+v, %v", order, err)
+	}
+}
+
+func TestWireMockWebhooks(t *testing.T) {
+	receiver := NewWebhookReceiver(t)
+
+	ctx := context.Background()
+	container, err := RunContainerAndStopOnCleanup(ctx, t,
+		WithImage(defaultV3WireMockImage),
+		WithHostPortAccess(receiver.Port()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = container.StubWebhooks(ctx,
+		wiremock.Post(wiremock.URLEqualTo("/orders")).
+			WillReturnResponse(wiremock.NewResponse().WithStatus(http.StatusAccepted)),
+		NewWebhook(http.MethodPost, receiver.URL("/order-created")).
+			WithHeader("Content-Type", "application/json").
+			WithBody(`{"id": "{{jsonPath originalRequest.body '$.id'}}"}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusCode, _, err := SendHttpPost(container, "/orders", strings.NewReader(`{"id": "42"}`))
+	if err != nil {
+		t.Fatal(err, "Failed to get a response")
+	}
+	if statusCode != 202 {
+		t.Fatalf("expected HTTP-202 but got %d", statusCode)
+	}
+
+	received := receiver.AssertReceived(t, 1, 10*time.Second)
+	if len(received) != 1 || received[0].URL != "/order-created" || received[0].Body.String() != `{"id": "42"}` {
+		t.Fatalf("unexpected webhooks %+v", received)
+	}
+}